@@ -1,10 +1,12 @@
 package authutils
 
-// AuthData contains the authentication data that is sent to the endpoints inside the context
+// AuthData contains the authentication data that is sent to the endpoints inside the context,
+// the password reset is set when the token can only be used to change the password
 type AuthData struct {
-	JwtID     string
-	UserID    string
-	UserName  string
-	UserEmail string
-	UserRole  string
+	JwtID         string
+	UserID        string
+	UserName      string
+	UserEmail     string
+	UserRole      string
+	PasswordReset bool
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 )
 
 // Config contains the configuration parameters for the app
@@ -13,6 +14,17 @@ type Config struct {
 	FirebaseAPIKey    string
 	JWTIssuer         string
 	JWTSignKey        string
+	MaxFolderDepth    int
+	ExecutionTimeout  int
+	MaxResponseSize   int
+	ScriptTimeout     int
+	ScriptMaxMemory   int
+	ScriptConcurrency int
+	RunWorkers        int
+	MaxRunIterations  int
+	MaxRunConcurrency int
+	AllowedNetworks   string
+	TrustedProxies    int
 }
 
 // New reads the app configurationa
@@ -25,5 +37,26 @@ func New() *Config {
 		FirebaseAPIKey:    os.Getenv("FIREBASE_API_KEY"),
 		JWTIssuer:         os.Getenv("JWT_ISSUER"),
 		JWTSignKey:        os.Getenv("JWT_SIGN_KEY"),
+		MaxFolderDepth:    getIntEnv("MAX_FOLDER_DEPTH", 10),
+		ExecutionTimeout:  getIntEnv("EXECUTION_TIMEOUT", 30),
+		MaxResponseSize:   getIntEnv("MAX_RESPONSE_SIZE", 10*1024*1024),
+		ScriptTimeout:     getIntEnv("SCRIPT_TIMEOUT", 1000),
+		ScriptMaxMemory:   getIntEnv("SCRIPT_MAX_MEMORY", 32*1024*1024),
+		ScriptConcurrency: getIntEnv("SCRIPT_CONCURRENCY", 8),
+		RunWorkers:        getIntEnv("RUN_WORKERS", 4),
+		MaxRunIterations:  getIntEnv("MAX_RUN_ITERATIONS", 100),
+		MaxRunConcurrency: getIntEnv("MAX_RUN_CONCURRENCY", 4),
+		AllowedNetworks:   os.Getenv("ALLOWED_NETWORKS"),
+		TrustedProxies:    getIntEnv("TRUSTED_PROXIES", 1),
 	}
 }
+
+// getIntEnv reads an integer environment variable, or returns the default value if it is not set or invalid
+func getIntEnv(name string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
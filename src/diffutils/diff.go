@@ -0,0 +1,66 @@
+package diffutils
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Change contains the values of a field before and after a modification
+type Change struct {
+	Before interface{} `json:"before" firestore:"before"`
+	After  interface{} `json:"after" firestore:"after"`
+}
+
+// Diff compares the JSON representation of two values field by field and returns the changed fields,
+// a nil value is treated as an object without fields and the ignored fields are never compared
+func Diff(before, after interface{}, ignored ...string) (map[string]*Change, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]*Change{}
+
+	for key, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			changes[key] = &Change{Before: value, After: afterFields[key]}
+		}
+	}
+
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok && value != nil {
+			changes[key] = &Change{Before: nil, After: value}
+		}
+	}
+
+	for _, key := range ignored {
+		delete(changes, key)
+	}
+
+	return changes, nil
+}
+
+// toFields returns the fields of the JSON representation of a value
+func toFields(v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
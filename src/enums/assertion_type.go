@@ -0,0 +1,32 @@
+package enums
+
+const (
+	// AssertionTypeStatusEquals is the type of the assertions that check the status code of the response
+	AssertionTypeStatusEquals = "status_equals"
+
+	// AssertionTypeHeaderPresent is the type of the assertions that check if the response has a header
+	AssertionTypeHeaderPresent = "header_present"
+
+	// AssertionTypeJSONPathEquals is the type of the assertions that compare a value of a JSON body with an expected value
+	AssertionTypeJSONPathEquals = "json_path_equals"
+
+	// AssertionTypeJSONPathMatches is the type of the assertions that match a value of a JSON body with a regular expression
+	AssertionTypeJSONPathMatches = "json_path_matches"
+
+	// AssertionTypeResponseTimeBelow is the type of the assertions that check the response time in milliseconds
+	AssertionTypeResponseTimeBelow = "response_time_below"
+
+	// AssertionTypeJSONSchema is the type of the assertions that validate a JSON body with a JSON Schema
+	AssertionTypeJSONSchema = "json_schema"
+)
+
+// IsValidAssertionType return valid assertion type
+func IsValidAssertionType(assertionType string) bool {
+	if assertionType == AssertionTypeStatusEquals || assertionType == AssertionTypeHeaderPresent ||
+		assertionType == AssertionTypeJSONPathEquals || assertionType == AssertionTypeJSONPathMatches ||
+		assertionType == AssertionTypeResponseTimeBelow || assertionType == AssertionTypeJSONSchema {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,129 @@
+package enums
+
+const (
+	// AuditActionUpdateUser is the action of updating a user
+	AuditActionUpdateUser = "update_user"
+
+	// AuditActionDeleteUser is the action of deleting a user
+	AuditActionDeleteUser = "delete_user"
+
+	// AuditActionDisableUser is the action of disabling a user
+	AuditActionDisableUser = "disable_user"
+
+	// AuditActionEnableUser is the action of enabling a user
+	AuditActionEnableUser = "enable_user"
+
+	// AuditActionForceResetPassword is the action of forcing a user to reset its password
+	AuditActionForceResetPassword = "force_reset_password"
+
+	// AuditActionSetUserRole is the action of changing the role of a user
+	AuditActionSetUserRole = "set_user_role"
+
+	// AuditActionCreateProject is the action of creating a project
+	AuditActionCreateProject = "create_project"
+
+	// AuditActionUpdateProject is the action of updating a project
+	AuditActionUpdateProject = "update_project"
+
+	// AuditActionDeleteProject is the action of deleting a project
+	AuditActionDeleteProject = "delete_project"
+
+	// AuditActionDuplicateProject is the action of duplicating a project with its folders, requests and environments
+	AuditActionDuplicateProject = "duplicate_project"
+
+	// AuditActionImportProject is the action of creating a project from a collection of another tool
+	AuditActionImportProject = "import_project"
+
+	// AuditActionCreateProjectUser is the action of sharing a project with a user
+	AuditActionCreateProjectUser = "create_projectuser"
+
+	// AuditActionDeleteProjectUser is the action of removing a user from a project
+	AuditActionDeleteProjectUser = "delete_projectuser"
+
+	// AuditActionCreateFolder is the action of creating a folder
+	AuditActionCreateFolder = "create_folder"
+
+	// AuditActionUpdateFolder is the action of updating a folder
+	AuditActionUpdateFolder = "update_folder"
+
+	// AuditActionDeleteFolder is the action of deleting a folder
+	AuditActionDeleteFolder = "delete_folder"
+
+	// AuditActionDuplicateFolder is the action of duplicating a folder with its subfolders and requests
+	AuditActionDuplicateFolder = "duplicate_folder"
+
+	// AuditActionRestoreFolder is the action of restoring a deleted folder with its subfolders and requests
+	AuditActionRestoreFolder = "restore_folder"
+
+	// AuditActionReorderFolder is the action of moving a folder to another position
+	AuditActionReorderFolder = "reorder_folder"
+
+	// AuditActionMoveFolder is the action of moving a folder with its subfolders and requests
+	AuditActionMoveFolder = "move_folder"
+
+	// AuditActionCopyFolder is the action of copying a folder with its subfolders and requests
+	AuditActionCopyFolder = "copy_folder"
+
+	// AuditActionCreateRequest is the action of creating a request
+	AuditActionCreateRequest = "create_request"
+
+	// AuditActionUpdateRequest is the action of updating a request
+	AuditActionUpdateRequest = "update_request"
+
+	// AuditActionDeleteRequest is the action of deleting a request
+	AuditActionDeleteRequest = "delete_request"
+
+	// AuditActionDuplicateRequest is the action of duplicating a request
+	AuditActionDuplicateRequest = "duplicate_request"
+
+	// AuditActionReorderRequest is the action of moving a request to another position
+	AuditActionReorderRequest = "reorder_request"
+
+	// AuditActionMoveRequest is the action of moving a request to another folder or project
+	AuditActionMoveRequest = "move_request"
+
+	// AuditActionCopyRequest is the action of copying a request to another folder or project
+	AuditActionCopyRequest = "copy_request"
+
+	// AuditActionImportRequest is the action of creating a request from a command line of another tool
+	AuditActionImportRequest = "import_request"
+
+	// AuditActionRestoreRequestRevision is the action of restoring a revision of a request
+	AuditActionRestoreRequestRevision = "restore_request_revision"
+
+	// AuditActionCreateEnvironment is the action of creating an environment
+	AuditActionCreateEnvironment = "create_environment"
+
+	// AuditActionUpdateEnvironment is the action of updating an environment
+	AuditActionUpdateEnvironment = "update_environment"
+
+	// AuditActionDeleteEnvironment is the action of deleting an environment
+	AuditActionDeleteEnvironment = "delete_environment"
+
+	// AuditActionDuplicateEnvironment is the action of duplicating an environment
+	AuditActionDuplicateEnvironment = "duplicate_environment"
+
+	// AuditActionMoveEnvironment is the action of moving an environment to another project
+	AuditActionMoveEnvironment = "move_environment"
+
+	// AuditActionCopyEnvironment is the action of copying an environment to another project
+	AuditActionCopyEnvironment = "copy_environment"
+
+	// AuditActionRestoreEnvironmentRevision is the action of restoring a revision of an environment
+	AuditActionRestoreEnvironmentRevision = "restore_environment_revision"
+
+	// AuditActionUploadBlob is the action of uploading a file for the body of the requests
+	AuditActionUploadBlob = "upload_blob"
+
+	// AuditActionSaveResponse is the action of saving a response as an example of a request
+	AuditActionSaveResponse = "save_response"
+
+	// AuditActionDeleteResponse is the action of deleting a saved response
+	AuditActionDeleteResponse = "delete_response"
+
+	// AuditActionStartRun is the action of starting a run of the requests of a folder or project
+	AuditActionStartRun = "start_run"
+
+	// AuditActionCancelRun is the action of cancelling a run
+	AuditActionCancelRun = "cancel_run"
+)
@@ -0,0 +1,55 @@
+package enums
+
+const (
+	// AuthTypeInherit is the type of the auth that uses the auth of the parent folder or project
+	AuthTypeInherit = "inherit"
+
+	// AuthTypeNone is the type of the auth that does not authenticate the requests
+	AuthTypeNone = "none"
+
+	// AuthTypeBasic is the type of the HTTP Basic auth
+	AuthTypeBasic = "basic"
+
+	// AuthTypeBearer is the type of the auth with a bearer token
+	AuthTypeBearer = "bearer"
+
+	// AuthTypeAPIKey is the type of the auth with an API key sent in a header or in the query string
+	AuthTypeAPIKey = "apikey"
+
+	// AuthTypeDigest is the type of the HTTP Digest auth
+	AuthTypeDigest = "digest"
+
+	// AuthTypeAWSV4 is the type of the auth with AWS Signature Version 4
+	AuthTypeAWSV4 = "awsv4"
+
+	// AuthTypeOAuth2 is the type of the auth with an OAuth2 access token
+	AuthTypeOAuth2 = "oauth2"
+)
+
+// IsValidAuthType return valid auth type
+func IsValidAuthType(authType string) bool {
+	if authType == AuthTypeInherit || authType == AuthTypeNone ||
+		authType == AuthTypeBasic || authType == AuthTypeBearer ||
+		authType == AuthTypeAPIKey || authType == AuthTypeDigest ||
+		authType == AuthTypeAWSV4 || authType == AuthTypeOAuth2 {
+		return true
+	}
+
+	return false
+}
+
+const (
+	// APIKeyInHeader is the location of the API keys sent in a header
+	APIKeyInHeader = "header"
+
+	// APIKeyInQuery is the location of the API keys sent in the query string
+	APIKeyInQuery = "query"
+)
+
+const (
+	// OAuth2GrantClientCredentials is the OAuth2 client credentials grant
+	OAuth2GrantClientCredentials = "client_credentials"
+
+	// OAuth2GrantPassword is the OAuth2 resource owner password credentials grant
+	OAuth2GrantPassword = "password"
+)
@@ -0,0 +1,40 @@
+package enums
+
+const (
+	// BodyModeNone is the mode of the requests without body
+	BodyModeNone = "none"
+
+	// BodyModeRaw is the mode of the requests with a text body of any content type
+	BodyModeRaw = "raw"
+
+	// BodyModeURLEncoded is the mode of the requests with an x-www-form-urlencoded body
+	BodyModeURLEncoded = "urlencoded"
+
+	// BodyModeFormData is the mode of the requests with a multipart/form-data body
+	BodyModeFormData = "formdata"
+
+	// BodyModeBinary is the mode of the requests with the content of a file as body
+	BodyModeBinary = "binary"
+
+	// BodyModeGraphQL is the mode of the requests with a GraphQL query and its variables as body
+	BodyModeGraphQL = "graphql"
+)
+
+// IsValidBodyMode return valid body mode
+func IsValidBodyMode(mode string) bool {
+	if mode == BodyModeNone || mode == BodyModeRaw ||
+		mode == BodyModeURLEncoded || mode == BodyModeFormData ||
+		mode == BodyModeBinary || mode == BodyModeGraphQL {
+		return true
+	}
+
+	return false
+}
+
+const (
+	// MultipartPartTypeText is the type of the multipart parts with a text value
+	MultipartPartTypeText = "text"
+
+	// MultipartPartTypeFile is the type of the multipart parts with the content of a file
+	MultipartPartTypeFile = "file"
+)
@@ -0,0 +1,21 @@
+package enums
+
+const (
+	// CaptureSourceJSONPath is the source of the captures that read a value of a JSON body
+	CaptureSourceJSONPath = "json_path"
+
+	// CaptureSourceHeader is the source of the captures that read a header of the response
+	CaptureSourceHeader = "header"
+
+	// CaptureSourceRegex is the source of the captures that match the body with a regular expression
+	CaptureSourceRegex = "regex"
+)
+
+// IsValidCaptureSource return valid capture source
+func IsValidCaptureSource(captureSource string) bool {
+	if captureSource == CaptureSourceJSONPath || captureSource == CaptureSourceHeader || captureSource == CaptureSourceRegex {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package enums
+
+const (
+	// EntityTypeUser is the type of the users
+	EntityTypeUser = "user"
+
+	// EntityTypeProject is the type of the projects
+	EntityTypeProject = "project"
+
+	// EntityTypeProjectUser is the type of the relationships between projects and users
+	EntityTypeProjectUser = "projectuser"
+
+	// EntityTypeFolder is the type of the folders
+	EntityTypeFolder = "folder"
+
+	// EntityTypeRequest is the type of the requests
+	EntityTypeRequest = "request"
+
+	// EntityTypeEnvironment is the type of the environments
+	EntityTypeEnvironment = "environment"
+
+	// EntityTypeBlob is the type of the files used in the body of the requests
+	EntityTypeBlob = "blob"
+
+	// EntityTypeResponse is the type of the responses saved as examples of the requests
+	EntityTypeResponse = "response"
+
+	// EntityTypeRun is the type of the runs of the requests of a folder or project
+	EntityTypeRun = "run"
+)
+
+// IsValidEntityType return valid entity type
+func IsValidEntityType(entityType string) bool {
+	if entityType == EntityTypeUser || entityType == EntityTypeProject ||
+		entityType == EntityTypeProjectUser || entityType == EntityTypeFolder ||
+		entityType == EntityTypeRequest || entityType == EntityTypeEnvironment ||
+		entityType == EntityTypeBlob || entityType == EntityTypeResponse ||
+		entityType == EntityTypeRun {
+		return true
+	}
+
+	return false
+}
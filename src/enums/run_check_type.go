@@ -0,0 +1,12 @@
+package enums
+
+const (
+	// RunCheckTypeAssertion is the type of the checks of the assertions of a request
+	RunCheckTypeAssertion = "assertion"
+
+	// RunCheckTypeCapture is the type of the checks of the captures of a request
+	RunCheckTypeCapture = "capture"
+
+	// RunCheckTypeTest is the type of the checks of the tests defined by the scripts
+	RunCheckTypeTest = "test"
+)
@@ -0,0 +1,31 @@
+package enums
+
+const (
+	// RunStatusQueued is the status of the runs waiting for a worker
+	RunStatusQueued = "queued"
+
+	// RunStatusRunning is the status of the runs executing their requests
+	RunStatusRunning = "running"
+
+	// RunStatusPassed is the status of the finished runs whose requests passed
+	RunStatusPassed = "passed"
+
+	// RunStatusFailed is the status of the finished runs with a failed request
+	RunStatusFailed = "failed"
+
+	// RunStatusCancelled is the status of the runs cancelled by a user
+	RunStatusCancelled = "cancelled"
+
+	// RunStatusError is the status of the runs stopped by an internal error
+	RunStatusError = "error"
+)
+
+// IsFinishedRunStatus returns if a run with the status is finished
+func IsFinishedRunStatus(runStatus string) bool {
+	if runStatus == RunStatusPassed || runStatus == RunStatusFailed ||
+		runStatus == RunStatusCancelled || runStatus == RunStatusError {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package enums
+
+const (
+	// SnippetLanguageCurl is the language of the snippets with a curl command line
+	SnippetLanguageCurl = "curl"
+
+	// SnippetLanguageGo is the language of the snippets with a Go program using net/http
+	SnippetLanguageGo = "go"
+
+	// SnippetLanguagePython is the language of the snippets with a Python script using requests
+	SnippetLanguagePython = "python"
+
+	// SnippetLanguageJavaScript is the language of the snippets with JavaScript code using fetch
+	SnippetLanguageJavaScript = "javascript"
+
+	// SnippetLanguageHTTPie is the language of the snippets with an HTTPie command line
+	SnippetLanguageHTTPie = "httpie"
+)
+
+// IsValidSnippetLanguage return valid snippet language
+func IsValidSnippetLanguage(language string) bool {
+	if language == SnippetLanguageCurl || language == SnippetLanguageGo ||
+		language == SnippetLanguagePython || language == SnippetLanguageJavaScript ||
+		language == SnippetLanguageHTTPie {
+		return true
+	}
+
+	return false
+}
@@ -7,3 +7,12 @@ const (
 	// UserRoleUser is the role for common users
 	UserRoleUser = "user"
 )
+
+// IsValidUserRole return valid user role
+func IsValidUserRole(role string) bool {
+	if role == UserRoleAdmin || role == UserRoleUser {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"fmt"
+
+	"apiboy/backend/src/logger"
+)
+
+// Conflict is returned when an object was modified by someone else since it was read,
+// it includes the current copy of the object stored in the server
+type Conflict struct {
+	Obj     string
+	Current interface{}
+}
+
+// Error returns a string message for this error
+func (e Conflict) Error() string {
+	msg := "Conflicting version"
+
+	if e.Obj == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %v", msg, e.Obj)
+}
+
+// LogFields returns the fields for logging this error
+func (e Conflict) LogFields() []logger.Field {
+	return []logger.Field{
+		logger.Field{Key: "Obj", Val: e.Obj},
+	}
+}
@@ -0,0 +1,268 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// AssertionResult is the result of an assertion of a request, the message describes the check or why it failed
+type AssertionResult struct {
+	Assertion *store.Assertion `json:"assertion"`
+	Passed    bool             `json:"passed"`
+	Message   string           `json:"message"`
+}
+
+// CheckAssertion validates the settings of an assertion, the values that reference variables
+// are only checked when the assertion runs
+func CheckAssertion(assertion *store.Assertion) error {
+	if !enums.IsValidAssertionType(assertion.Type) {
+		return fmt.Errorf("invalid assertion type %s", assertion.Type)
+	}
+
+	switch assertion.Type {
+	case enums.AssertionTypeHeaderPresent:
+		if strings.TrimSpace(assertion.Property) == "" {
+			return fmt.Errorf("missing header name")
+		}
+
+	case enums.AssertionTypeJSONPathEquals, enums.AssertionTypeJSONPathMatches:
+		if _, err := parseJSONPath(assertion.Property); err != nil {
+			return err
+		}
+	}
+
+	if strings.Contains(assertion.Value, "{{") {
+		return nil
+	}
+
+	switch assertion.Type {
+	case enums.AssertionTypeStatusEquals, enums.AssertionTypeResponseTimeBelow:
+		if _, err := strconv.Atoi(strings.TrimSpace(assertion.Value)); err != nil {
+			return fmt.Errorf("invalid number %s", assertion.Value)
+		}
+
+	case enums.AssertionTypeJSONPathMatches:
+		if _, err := regexp.Compile(assertion.Value); err != nil {
+			return fmt.Errorf("invalid regular expression: %v", err)
+		}
+
+	case enums.AssertionTypeJSONSchema:
+		if _, err := loadJSONSchema(assertion.Value); err != nil {
+			return fmt.Errorf("invalid JSON Schema: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// RunAssertions checks the enabled assertions of a request against its response,
+// the references to variables of the values are replaced before the checks
+func RunAssertions(assertions []*store.Assertion, response *Response, variables map[string]string) []*AssertionResult {
+	results := []*AssertionResult{}
+	body := &jsonBody{data: response.Body}
+
+	for _, assertion := range assertions {
+		if !assertion.Enabled {
+			continue
+		}
+
+		passed, message := runAssertion(assertion, response, body, requestutils.ReplaceVariables(assertion.Value, variables))
+
+		results = append(results, &AssertionResult{
+			Assertion: assertion,
+			Passed:    passed,
+			Message:   message,
+		})
+	}
+
+	return results
+}
+
+// jsonBody is the body of a response, decoded as JSON the first time it is needed
+type jsonBody struct {
+	data     string
+	decoded  bool
+	document interface{}
+	err      error
+}
+
+// get returns the decoded body
+func (b *jsonBody) get() (interface{}, error) {
+	if !b.decoded {
+		b.decoded = true
+		b.err = json.Unmarshal([]byte(b.data), &b.document)
+	}
+
+	return b.document, b.err
+}
+
+// runAssertion checks an assertion, and returns if it passed with a message
+func runAssertion(assertion *store.Assertion, response *Response, body *jsonBody, value string) (bool, string) {
+	switch assertion.Type {
+	case enums.AssertionTypeStatusEquals:
+		expected, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return false, fmt.Sprintf("Invalid status code %s", value)
+		}
+
+		if response.StatusCode != expected {
+			return false, fmt.Sprintf("Expected status code %d, got %d", expected, response.StatusCode)
+		}
+
+		return true, fmt.Sprintf("Status code is %d", expected)
+
+	case enums.AssertionTypeHeaderPresent:
+		if _, ok := response.header(assertion.Property); !ok {
+			return false, fmt.Sprintf("Header %s is missing", assertion.Property)
+		}
+
+		return true, fmt.Sprintf("Header %s is present", assertion.Property)
+
+	case enums.AssertionTypeResponseTimeBelow:
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return false, fmt.Sprintf("Invalid response time %s", value)
+		}
+
+		if response.Time >= int64(limit) {
+			return false, fmt.Sprintf("Response time %d ms is not below %d ms", response.Time, limit)
+		}
+
+		return true, fmt.Sprintf("Response time %d ms is below %d ms", response.Time, limit)
+
+	case enums.AssertionTypeJSONPathEquals, enums.AssertionTypeJSONPathMatches:
+		return runJSONPathAssertion(assertion, body, value)
+
+	case enums.AssertionTypeJSONSchema:
+		if _, err := body.get(); err != nil {
+			return false, "The body is not valid JSON"
+		}
+
+		schema, err := loadJSONSchema(value)
+		if err != nil {
+			return false, fmt.Sprintf("Invalid JSON Schema: %v", err)
+		}
+
+		result, err := schema.Validate(gojsonschema.NewStringLoader(body.data))
+		if err != nil {
+			return false, fmt.Sprintf("Invalid JSON Schema: %v", err)
+		}
+
+		if !result.Valid() {
+			errs := []string{}
+			for _, e := range result.Errors() {
+				errs = append(errs, e.String())
+			}
+
+			return false, "The body does not match the JSON Schema: " + strings.Join(errs, "; ")
+		}
+
+		return true, "The body matches the JSON Schema"
+	}
+
+	return false, fmt.Sprintf("Invalid assertion type %s", assertion.Type)
+}
+
+// runJSONPathAssertion compares or matches the value of a JSON body at a path
+func runJSONPathAssertion(assertion *store.Assertion, body *jsonBody, value string) (bool, string) {
+	path := assertion.Property
+
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid JSON path: %v", err)
+	}
+
+	document, err := body.get()
+	if err != nil {
+		return false, "The body is not valid JSON"
+	}
+
+	actual, ok := evalJSONPath(document, segments)
+	if !ok {
+		return false, fmt.Sprintf("No value at %s", path)
+	}
+
+	if assertion.Type == enums.AssertionTypeJSONPathMatches {
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return false, fmt.Sprintf("Invalid regular expression: %v", err)
+		}
+
+		text := jsonText(actual)
+		if !pattern.MatchString(text) {
+			return false, fmt.Sprintf("Value %s at %s does not match %s", text, path, value)
+		}
+
+		return true, fmt.Sprintf("Value at %s matches %s", path, value)
+	}
+
+	// the expected value is compared as JSON, or as a string when it is not valid JSON
+	var expected interface{} = value
+	json.Unmarshal([]byte(value), &expected)
+
+	if !reflect.DeepEqual(actual, expected) && actual != value {
+		return false, fmt.Sprintf("Expected %s at %s, got %s", jsonText(expected), path, jsonText(actual))
+	}
+
+	return true, fmt.Sprintf("Value at %s equals %s", path, jsonText(expected))
+}
+
+// jsonText returns a decoded JSON value as text, the strings are not quoted
+func jsonText(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// loadJSONSchema parses a JSON Schema, the references must be fragments of the same schema,
+// because the library loads the other ones from the network or the file system
+func loadJSONSchema(value string) (*gojsonschema.Schema, error) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(value), &document); err != nil {
+		return nil, err
+	}
+
+	if err := checkSchemaRefs(document); err != nil {
+		return nil, err
+	}
+
+	return gojsonschema.NewSchema(gojsonschema.NewGoLoader(document))
+}
+
+// checkSchemaRefs returns an error if a value of a schema has a $ref that is not a local fragment
+func checkSchemaRefs(value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" && !strings.HasPrefix(ref, "#") {
+				return fmt.Errorf("the reference %q is not allowed, only references to the same schema starting with # are supported", ref)
+			}
+
+			if err := checkSchemaRefs(item); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if err := checkSchemaRefs(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
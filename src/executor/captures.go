@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+)
+
+// CaptureResult is the result of a capture of a request, the message describes why the value was not captured
+type CaptureResult struct {
+	Capture  *store.Capture `json:"capture"`
+	Captured bool           `json:"captured"`
+	Value    string         `json:"value"`
+	Message  string         `json:"message"`
+}
+
+// CheckCapture validates the settings of a capture
+func CheckCapture(capture *store.Capture) error {
+	if !enums.IsValidCaptureSource(capture.Source) {
+		return fmt.Errorf("invalid capture source %s", capture.Source)
+	}
+
+	if strings.TrimSpace(capture.Variable) == "" {
+		return fmt.Errorf("missing variable name")
+	}
+
+	switch capture.Source {
+	case enums.CaptureSourceJSONPath:
+		if _, err := parseJSONPath(capture.Property); err != nil {
+			return err
+		}
+
+	case enums.CaptureSourceHeader:
+		if strings.TrimSpace(capture.Property) == "" {
+			return fmt.Errorf("missing header name")
+		}
+
+	case enums.CaptureSourceRegex:
+		if _, err := regexp.Compile(capture.Property); err != nil {
+			return fmt.Errorf("invalid regular expression: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// RunCaptures extracts the values of the enabled captures of a request from its response.
+// The values are only captured when the request succeeded, that is when the response has
+// a 2xx status code and all the assertions passed.
+func RunCaptures(captures []*store.Capture, response *Response, assertions []*AssertionResult) []*CaptureResult {
+	results := []*CaptureResult{}
+	body := &jsonBody{data: response.Body}
+	successful := response.StatusCode >= 200 && response.StatusCode < 300
+
+	assertionsPassed := true
+	for _, assertion := range assertions {
+		assertionsPassed = assertionsPassed && assertion.Passed
+	}
+
+	for _, capture := range captures {
+		if !capture.Enabled {
+			continue
+		}
+
+		result := &CaptureResult{Capture: capture}
+
+		if !successful {
+			result.Message = fmt.Sprintf("Not captured, the status code %d is not successful", response.StatusCode)
+		} else if !assertionsPassed {
+			result.Message = "Not captured, the assertions of the request failed"
+		} else {
+			result.Value, result.Captured, result.Message = runCapture(capture, response, body)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// runCapture extracts the value of a capture, and returns if it was captured with a message
+func runCapture(capture *store.Capture, response *Response, body *jsonBody) (string, bool, string) {
+	switch capture.Source {
+	case enums.CaptureSourceJSONPath:
+		segments, err := parseJSONPath(capture.Property)
+		if err != nil {
+			return "", false, fmt.Sprintf("Invalid JSON path: %v", err)
+		}
+
+		document, err := body.get()
+		if err != nil {
+			return "", false, "The body is not valid JSON"
+		}
+
+		value, ok := evalJSONPath(document, segments)
+		if !ok {
+			return "", false, fmt.Sprintf("No value at %s", capture.Property)
+		}
+
+		return jsonText(value), true, fmt.Sprintf("Captured the value at %s", capture.Property)
+
+	case enums.CaptureSourceHeader:
+		value, ok := response.header(capture.Property)
+		if !ok {
+			return "", false, fmt.Sprintf("Header %s is missing", capture.Property)
+		}
+
+		return value, true, fmt.Sprintf("Captured the header %s", capture.Property)
+
+	case enums.CaptureSourceRegex:
+		pattern, err := regexp.Compile(capture.Property)
+		if err != nil {
+			return "", false, fmt.Sprintf("Invalid regular expression: %v", err)
+		}
+
+		match := pattern.FindStringSubmatch(response.Body)
+		if match == nil {
+			return "", false, fmt.Sprintf("The body does not match %s", capture.Property)
+		}
+
+		// the first group is captured, or the whole match when the expression has no groups
+		if len(match) > 1 {
+			return match[1], true, fmt.Sprintf("Captured the first group of %s", capture.Property)
+		}
+
+		return match[0], true, fmt.Sprintf("Captured the match of %s", capture.Property)
+	}
+
+	return "", false, fmt.Sprintf("Invalid capture source %s", capture.Source)
+}
@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxRedirects is the maximum number of redirects followed by the client
+const maxRedirects = 10
+
+// blockedNetworks are the networks that the requests can't reach unless they are allowed:
+// the unspecified, loopback, private, shared and link-local addresses
+var blockedNetworks = mustParseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// NewClient returns the client used to send the requests, it refuses to connect to the blocked
+// addresses after the host names are resolved, unless they are in the allowed networks. The redirects
+// are checked too, so a public server can't redirect the requests to an internal address.
+func NewClient(timeout time.Duration, allowedNetworks []*net.IPNet) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			return checkIP(net.ParseIP(host), allowedNetworks)
+		},
+	}
+
+	// the proxies of the environment are not used, because the addresses are checked when dialing
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return checkHost(req.Context(), req.URL.Hostname(), allowedNetworks)
+		},
+	}
+}
+
+// ParseNetworks parses a comma separated list of networks in CIDR notation or single IP addresses
+func ParseNetworks(value string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid network %q", item)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q", item)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// mustParseNetworks parses a list of networks, it panics if any of them is not valid
+func mustParseNetworks(values ...string) []*net.IPNet {
+	networks, err := ParseNetworks(strings.Join(values, ","))
+	if err != nil {
+		panic(err)
+	}
+
+	return networks
+}
+
+// checkHost resolves a host and checks its addresses
+func checkHost(ctx context.Context, host string, allowedNetworks []*net.IPNet) error {
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip, allowedNetworks)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if err := checkIP(addr.IP, allowedNetworks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkIP returns an error if an address is blocked and it is not in the allowed networks
+func checkIP(ip net.IP, allowedNetworks []*net.IPNet) error {
+	if ip == nil {
+		return fmt.Errorf("invalid address")
+	}
+
+	for _, network := range allowedNetworks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("the address %s is not allowed", ip)
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("the address %s is not allowed", ip)
+		}
+	}
+
+	return nil
+}
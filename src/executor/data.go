@@ -0,0 +1,79 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseDataFile returns the rows of a data file of a run, with the values of each row by column.
+// The JSON files contain an array of objects, whose values that are not strings are converted to JSON,
+// and the first row of the CSV files contains the names of the columns.
+func ParseDataFile(fileName, contentType string, data []byte) ([]map[string]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if strings.Contains(strings.ToLower(contentType), "json") || strings.HasSuffix(strings.ToLower(fileName), ".json") ||
+		bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return parseJSONData(data)
+	}
+
+	return parseCSVData(data)
+}
+
+// parseJSONData returns the rows of a JSON data file
+func parseJSONData(data []byte) ([]map[string]string, error) {
+	objects := []map[string]interface{}{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("the JSON data file must contain an array of objects: %v", err)
+	}
+
+	rows := []map[string]string{}
+
+	for _, object := range objects {
+		row := map[string]string{}
+
+		for key, value := range object {
+			if value != nil {
+				row[key] = jsonText(value)
+			} else {
+				row[key] = ""
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseCSVData returns the rows of a CSV data file, the missing values of a row are not set
+func parseCSVData(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV data file: %v", err)
+	} else if len(records) == 0 {
+		return nil, fmt.Errorf("the CSV data file has no header")
+	}
+
+	columns := records[0]
+	rows := []map[string]string{}
+
+	for _, record := range records[1:] {
+		row := map[string]string{}
+
+		for i, value := range record {
+			if i < len(columns) && strings.TrimSpace(columns[i]) != "" {
+				row[strings.TrimSpace(columns[i])] = value
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
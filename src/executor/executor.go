@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/scripts"
+	"apiboy/backend/src/store"
+)
+
+// Executor sends the requests of the projects from the server, and runs their scripts in a sandbox
+type Executor struct {
+	Client      *http.Client
+	MaxBodySize int
+	Scripts     *scripts.Sandbox
+}
+
+// New returns a new Executor, the bodies of the responses are truncated to the maximum size
+func New(client *http.Client, maxBodySize int, sandbox *scripts.Sandbox) *Executor {
+	return &Executor{
+		Client:      client,
+		MaxBodySize: maxBodySize,
+		Scripts:     sandbox,
+	}
+}
+
+// Response is the response of an executed request, the time is in milliseconds
+type Response struct {
+	StatusCode int            `json:"status_code"`
+	Status     string         `json:"status"`
+	Headers    []*store.Param `json:"headers"`
+	Body       string         `json:"body"`
+	Size       int            `json:"size"`
+	Truncated  bool           `json:"truncated"`
+	Time       int64          `json:"time"`
+}
+
+// header returns the value of a header of the response, and if the response has the header
+func (r *Response) header(key string) (string, bool) {
+	for _, header := range r.Headers {
+		if strings.EqualFold(header.Key, key) {
+			return header.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Execute sends a resolved request with its body, and reads its response. The Digest auth is
+// applied when the server responds with a 401 status code, and the request is sent again.
+func (e *Executor) Execute(ctx context.Context, resolved *requestutils.ResolvedRequest, request *store.Request, getBlob requestutils.BlobGetter) (*Response, error) {
+	// the errors of the body are returned as they are, so the errors of getBlob are not hidden
+	body, contentType, err := requestutils.BuildBody(requestutils.WithBodyVariables(request, resolved.VariableValues()), getBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest(resolved.Method, resolved.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid url: %v", err)
+		}
+
+		req = req.WithContext(ctx)
+
+		for _, header := range resolved.Headers {
+			req.Header.Add(header.Key, header.Value)
+		}
+
+		// the Host header is not sent from the headers of the request
+		if host := req.Header.Get("Host"); host != "" {
+			req.Host = host
+		}
+
+		if contentType != "" && req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		if err := requestutils.ApplyAuth(ctx, req, body, resolved.Auth, e.Client); err != nil {
+			return nil, err
+		}
+
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	res, err := e.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send the request: %v", err)
+	}
+
+	// the Digest auth is answered with the challenge of the server
+	if res.StatusCode == http.StatusUnauthorized && resolved.Auth.Type == enums.AuthTypeDigest && resolved.Auth.Digest != nil {
+		challenge := res.Header.Get("WWW-Authenticate")
+		io.Copy(ioutil.Discard, io.LimitReader(res.Body, int64(e.MaxBodySize)))
+		res.Body.Close()
+
+		if req, err = newRequest(); err != nil {
+			return nil, err
+		}
+
+		if err := requestutils.ApplyDigestAuth(req, challenge, resolved.Auth.Digest); err != nil {
+			return nil, err
+		}
+
+		if res, err = e.Client.Do(req); err != nil {
+			return nil, fmt.Errorf("could not send the request: %v", err)
+		}
+	}
+
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, int64(e.MaxBodySize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the response: %v", err)
+	}
+
+	response := &Response{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Headers:    []*store.Param{},
+		Time:       time.Since(start).Nanoseconds() / int64(time.Millisecond),
+	}
+
+	if len(data) > e.MaxBodySize {
+		data = data[:e.MaxBodySize]
+		response.Truncated = true
+	}
+
+	response.Body = string(data)
+	response.Size = len(data)
+
+	// the headers are sorted by key to get a stable order
+	keys := []string{}
+	for key := range res.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range res.Header[key] {
+			response.Headers = append(response.Headers, &store.Param{Key: key, Value: value, Enabled: true})
+		}
+	}
+
+	return response, nil
+}
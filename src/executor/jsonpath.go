@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSONPath parses a JSON path like $.items[0].name or $['key'], the $ at the start is optional.
+// The segments are the keys of the objects and the indexes of the arrays, the negative indexes
+// are counted from the end of the arrays. The wildcards and filters are not supported.
+func parseJSONPath(original string) ([]interface{}, error) {
+	path := strings.TrimPrefix(strings.TrimSpace(original), "$")
+
+	if path != "" && path[0] != '.' && path[0] != '[' {
+		path = "." + path
+	}
+
+	segments := []interface{}{}
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			end := i + 1
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+
+			key := path[i+1 : end]
+			if key == "" || key == "*" {
+				return nil, fmt.Errorf("invalid JSON path %s", original)
+			}
+
+			segments = append(segments, key)
+			i = end
+
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %s: missing ]", original)
+			}
+
+			// the quoted keys can contain ], so the end is the closing quote
+			if i+1 < len(path) && (path[i+1] == '\'' || path[i+1] == '"') {
+				closing := strings.IndexByte(path[i+2:], path[i+1])
+				if closing < 0 || i+2+closing+1 >= len(path) || path[i+2+closing+1] != ']' {
+					return nil, fmt.Errorf("invalid JSON path %s: unclosed key", original)
+				}
+
+				segments = append(segments, path[i+2:i+2+closing])
+				i += 2 + closing + 2
+				continue
+			}
+
+			inner := strings.TrimSpace(path[i+1 : i+end])
+
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON path %s: invalid index %s", original, inner)
+			}
+
+			segments = append(segments, index)
+			i += end + 1
+
+		default:
+			return nil, fmt.Errorf("invalid JSON path %s", original)
+		}
+	}
+
+	return segments, nil
+}
+
+// evalJSONPath returns the value of a decoded JSON document at a path, and if the value exists
+func evalJSONPath(document interface{}, segments []interface{}) (interface{}, bool) {
+	value := document
+
+	for _, segment := range segments {
+		switch s := segment.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			if value, ok = object[s]; !ok {
+				return nil, false
+			}
+
+		case int:
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			if s < 0 {
+				s += len(array)
+			}
+
+			if s < 0 || s >= len(array) {
+				return nil, false
+			}
+
+			value = array[s]
+		}
+	}
+
+	return value, true
+}
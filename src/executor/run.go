@@ -0,0 +1,264 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/scripts"
+	"apiboy/backend/src/store"
+)
+
+// Execution contains a request to run with its folders (from the nearest to the farthest),
+// its project and the selected environment (optional). The data contains the variables of an
+// iteration of a run, which override the variables of the environment.
+type Execution struct {
+	Request     *store.Request
+	Folders     []*store.Folder
+	Project     *store.Project
+	Environment *store.Environment
+	Data        map[string]string
+	GetBlob     requestutils.BlobGetter
+}
+
+// Result is the result of a run, the error describes why the request could not be sent or a script failed.
+// The environment changes are the variables set or unset (nil) by the scripts and the captures.
+type Result struct {
+	Response           *Response             `json:"response"`
+	Assertions         []*AssertionResult    `json:"assertions"`
+	Captures           []*CaptureResult      `json:"captures"`
+	Tests              []*scripts.TestResult `json:"tests"`
+	Logs               []string              `json:"logs"`
+	EnvironmentChanges map[string]*string    `json:"environment_changes"`
+	Passed             bool                  `json:"passed"`
+	Error              string                `json:"error,omitempty"`
+}
+
+// Run runs the pre-request scripts of the folders and the request, sends the request changed by them,
+// and then checks the assertions, extracts the captures into the environment if the request succeeded and runs the test scripts. The scripts of the folders run before
+// the scripts of the request, from the farthest folder to the nearest.
+// The errors of the blobs of the body are returned as they are, the other failures are part of the result.
+func (e *Executor) Run(ctx context.Context, execution *Execution) (*Result, error) {
+	request := execution.Request
+
+	result := &Result{
+		Assertions: []*AssertionResult{},
+		Captures:   []*CaptureResult{},
+		Tests:      []*scripts.TestResult{},
+		Logs:       []string{},
+	}
+
+	// the pre-request scripts get the request before the variables are replaced
+	var environmentVariables map[string]string
+	if execution.Environment != nil {
+		environmentVariables = execution.Environment.Variables
+	}
+
+	scriptRequest := &scripts.Request{
+		Method:  strings.ToUpper(request.Type),
+		URL:     request.URL,
+		Headers: copyParams(request.Headers),
+		Body:    request.Body,
+	}
+
+	resolved := requestutils.Resolve(request, execution.Folders, execution.Project, withData(execution.Environment, execution.Data))
+	c := scripts.NewContext(resolved.VariableValues(), environmentVariables, scriptRequest)
+	c.Data = execution.Data
+
+	err := e.runScripts(execution, c, false)
+
+	result.EnvironmentChanges = c.Changes
+	result.Logs = c.Logs
+
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	// the changes of the scripts are applied to copies of the request and the environment
+	request = applyRequestChanges(request, scriptRequest)
+	environment := applyEnvironmentChanges(execution.Environment, c.Changes)
+
+	// send the request, the errors of the blobs are kept to return them
+	var blobErr error
+
+	getBlob := func(id string) (*store.Blob, error) {
+		blob, err := execution.GetBlob(id)
+		if err != nil {
+			blobErr = err
+		}
+
+		return blob, err
+	}
+
+	resolved = requestutils.Resolve(request, execution.Folders, execution.Project, withData(environment, execution.Data))
+
+	response, err := e.Execute(ctx, resolved, request, getBlob)
+	if err != nil {
+		if blobErr != nil {
+			return nil, blobErr
+		}
+
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	result.Response = response
+	result.Assertions = RunAssertions(request.Assertions, response, resolved.VariableValues())
+
+	// the values are only captured when the request succeeded (2xx status and all the assertions passed),
+	// and they are set in the environment before the test scripts, so they can read them
+	result.Captures = RunCaptures(request.Captures, response, result.Assertions)
+
+	for _, capture := range result.Captures {
+		if capture.Captured {
+			c.SetEnvironmentVariable(capture.Capture.Variable, capture.Value)
+		}
+	}
+
+	// the test scripts get the response, and they can change the environment too
+	c.Response = &scripts.Response{
+		StatusCode: response.StatusCode,
+		Headers:    response.Headers,
+		Body:       response.Body,
+		Time:       response.Time,
+	}
+
+	err = e.runScripts(execution, c, true)
+
+	result.Tests = c.Tests
+	result.Logs = c.Logs
+
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	result.Passed = true
+
+	for _, assertion := range result.Assertions {
+		result.Passed = result.Passed && assertion.Passed
+	}
+
+	for _, test := range result.Tests {
+		result.Passed = result.Passed && test.Passed
+	}
+
+	return result, nil
+}
+
+// runScripts runs the pre-request or test scripts of the folders, from the farthest to the nearest,
+// and then the script of the request. It stops at the first script that fails.
+func (e *Executor) runScripts(execution *Execution, c *scripts.Context, test bool) error {
+	kind := "pre-request script"
+	if test {
+		kind = "test script"
+	}
+
+	run := func(name, preRequestScript, testScript string) error {
+		script := preRequestScript
+		if test {
+			script = testScript
+		}
+
+		if strings.TrimSpace(script) == "" {
+			return nil
+		}
+
+		return e.Scripts.Run(name+" "+kind, script, c)
+	}
+
+	for i := len(execution.Folders) - 1; i >= 0; i-- {
+		folder := execution.Folders[i]
+
+		if err := run(fmt.Sprintf("Folder %q", folder.Name), folder.PreRequestScript, folder.TestScript); err != nil {
+			return err
+		}
+	}
+
+	request := execution.Request
+
+	return run(fmt.Sprintf("Request %q", request.Name), request.PreRequestScript, request.TestScript)
+}
+
+// applyRequestChanges returns a copy of a request with the changes of the pre-request scripts.
+// The query params are parsed again when the url changes, and a changed body is sent as raw.
+func applyRequestChanges(request *store.Request, changed *scripts.Request) *store.Request {
+	copy := *request
+
+	copy.Type = changed.Method
+	copy.Headers = changed.Headers
+
+	if changed.URL != request.URL {
+		copy.URL = changed.URL
+		copy.QueryParams = requestutils.ParseQueryParams(changed.URL, request.QueryParams)
+	}
+
+	if changed.Body != request.Body {
+		copy.BodyMode = enums.BodyModeRaw
+		copy.Body = changed.Body
+	}
+
+	return &copy
+}
+
+// applyEnvironmentChanges returns a copy of an environment with the variables set or unset by the scripts,
+// an environment without id is returned when no environment is selected and the scripts set variables
+func applyEnvironmentChanges(environment *store.Environment, changes map[string]*string) *store.Environment {
+	if len(changes) == 0 {
+		return environment
+	}
+
+	copy := &store.Environment{}
+	if environment != nil {
+		*copy = *environment
+	}
+
+	copy.Variables = map[string]string{}
+	if environment != nil {
+		for name, value := range environment.Variables {
+			copy.Variables[name] = value
+		}
+	}
+
+	for name, value := range changes {
+		if value == nil {
+			delete(copy.Variables, name)
+		} else {
+			copy.Variables[name] = *value
+		}
+	}
+
+	return copy
+}
+
+// withData returns a copy of an environment with the variables of the data of an iteration,
+// an environment without id is returned when no environment is selected
+func withData(environment *store.Environment, data map[string]string) *store.Environment {
+	if len(data) == 0 {
+		return environment
+	}
+
+	changes := map[string]*string{}
+	for name := range data {
+		value := data[name]
+		changes[name] = &value
+	}
+
+	return applyEnvironmentChanges(environment, changes)
+}
+
+// copyParams returns a copy of a list of params, so the changes of the scripts don't modify the request
+func copyParams(params []*store.Param) []*store.Param {
+	copies := []*store.Param{}
+	for _, param := range params {
+		if param != nil {
+			p := *param
+			copies = append(copies, &p)
+		}
+	}
+
+	return copies
+}
@@ -0,0 +1,89 @@
+package exporters
+
+import (
+	"fmt"
+	"sort"
+
+	"apiboy/backend/src/rankutils"
+	"apiboy/backend/src/store"
+)
+
+// Project contains the documents of an exported project
+type Project struct {
+	Project      *store.Project
+	Folders      []*store.Folder
+	Requests     []*store.Request
+	Environments []*store.Environment
+	Responses    []*store.Response
+}
+
+// exporter indexes the folders and requests of a project by their parent folder, sorted by rank
+type exporter struct {
+	project  *Project
+	children map[string][]*store.Folder
+	requests map[string][]*store.Request
+	parents  map[string]*store.Folder
+	warnings []string
+}
+
+// newExporter returns a new exporter
+func newExporter(project *Project) *exporter {
+	e := &exporter{
+		project:  project,
+		children: map[string][]*store.Folder{},
+		requests: map[string][]*store.Request{},
+		parents:  map[string]*store.Folder{},
+		warnings: []string{},
+	}
+
+	folders := append([]*store.Folder{}, project.Folders...)
+	sort.SliceStable(folders, func(i, j int) bool {
+		return rankutils.Less(folders[i].Rank, folders[i].Name, folders[j].Rank, folders[j].Name)
+	})
+
+	requests := append([]*store.Request{}, project.Requests...)
+	sort.SliceStable(requests, func(i, j int) bool {
+		return rankutils.Less(requests[i].Rank, requests[i].Name, requests[j].Rank, requests[j].Name)
+	})
+
+	for _, folder := range folders {
+		e.children[folder.ParentFolderID] = append(e.children[folder.ParentFolderID], folder)
+		e.parents[folder.ID] = folder
+	}
+
+	for _, request := range requests {
+		e.requests[request.FolderID] = append(e.requests[request.FolderID], request)
+	}
+
+	return e
+}
+
+// folderChain returns the folders of a request, from the nearest to the farthest
+func (e *exporter) folderChain(request *store.Request) []*store.Folder {
+	chain := []*store.Folder{}
+	visited := map[string]bool{}
+
+	for folder := e.parents[request.FolderID]; folder != nil && !visited[folder.ID]; folder = e.parents[folder.ParentFolderID] {
+		visited[folder.ID] = true
+		chain = append(chain, folder)
+	}
+
+	return chain
+}
+
+// warn adds a warning about a part of the project that could not be exported
+func (e *exporter) warn(format string, args ...interface{}) {
+	e.warnings = append(e.warnings, fmt.Sprintf(format, args...))
+}
+
+// sortedKeys returns the keys of a map of strings sorted
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
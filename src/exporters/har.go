@@ -0,0 +1,370 @@
+package exporters
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// harVersion is the version of the exported HAR files
+const harVersion = "1.2"
+
+// HARLog is a HAR 1.2 file
+type HARLog struct {
+	Log *HARContent `json:"log"`
+}
+
+// HARContent is the content of a HAR file
+type HARContent struct {
+	Version string      `json:"version"`
+	Creator *HARCreator `json:"creator"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator is the application that created a HAR file
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a request with its response, the name of the request is the comment of the entry
+type HAREntry struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            int          `json:"time"`
+	Request         *HARRequest  `json:"request"`
+	Response        *HARResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *HARTimings  `json:"timings"`
+	Comment         string       `json:"comment,omitempty"`
+}
+
+// HARRequest is a request of a HAR file
+type HARRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []*HARPair   `json:"cookies"`
+	Headers     []*HARPair   `json:"headers"`
+	QueryString []*HARPair   `json:"queryString"`
+	PostData    *HARPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+// HARResponse is a response of a HAR file
+type HARResponse struct {
+	Status      int        `json:"status"`
+	StatusText  string     `json:"statusText"`
+	HTTPVersion string     `json:"httpVersion"`
+	Cookies     []*HARPair `json:"cookies"`
+	Headers     []*HARPair `json:"headers"`
+	Content     *HARBody   `json:"content"`
+	RedirectURL string     `json:"redirectURL"`
+	HeadersSize int        `json:"headersSize"`
+	BodySize    int        `json:"bodySize"`
+}
+
+// HARPair is a header, cookie, query param or form param of a HAR file
+type HARPair struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// HARPostData is the body of a request of a HAR file
+type HARPostData struct {
+	MimeType string     `json:"mimeType"`
+	Params   []*HARPair `json:"params"`
+	Text     string     `json:"text"`
+}
+
+// HARBody is the body of a response of a HAR file
+type HARBody struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings are the timings of an entry, the exported entries were not sent
+type HARTimings struct {
+	Send    int `json:"send"`
+	Wait    int `json:"wait"`
+	Receive int `json:"receive"`
+}
+
+// HARExport contains the requests of a project exported to HAR
+type HARExport struct {
+	Log      *HARLog
+	Warnings []string
+}
+
+// ExportHAR converts the requests of a project into a HAR 1.2 file, with the latest saved response of
+// each request. The references to variables are replaced with the values of the project, its folders
+// and the environment, which can be nil. The auths are not applied to the requests.
+func ExportHAR(project *Project, environment *store.Environment) *HARExport {
+	e := newExporter(project)
+
+	latest := map[string]*store.Response{}
+	for _, response := range project.Responses {
+		if current := latest[response.RequestID]; current == nil || eventTime(response.Created).After(eventTime(current.Created)) {
+			latest[response.RequestID] = response
+		}
+	}
+
+	log := &HARLog{Log: &HARContent{
+		Version: harVersion,
+		Creator: &HARCreator{Name: "ApiBoy", Version: "1.0"},
+		Entries: []*HAREntry{},
+	}}
+
+	auth := false
+
+	var exportFolder func(folderID string)
+	exportFolder = func(folderID string) {
+		for _, folder := range e.children[folderID] {
+			exportFolder(folder.ID)
+		}
+
+		for _, request := range e.requests[folderID] {
+			entry, authenticated := e.harEntry(request, environment, latest[request.ID])
+			if entry != nil {
+				log.Log.Entries = append(log.Log.Entries, entry)
+			}
+
+			auth = auth || authenticated
+		}
+	}
+
+	exportFolder("")
+
+	if auth {
+		e.warn("The auth of the requests was not exported")
+	}
+
+	return &HARExport{
+		Log:      log,
+		Warnings: e.warnings,
+	}
+}
+
+// harEntry converts a request and its response, it also returns if the request uses an auth
+func (e *exporter) harEntry(request *store.Request, environment *store.Environment, response *store.Response) (*HAREntry, bool) {
+	resolved := requestutils.Resolve(request, e.folderChain(request), e.project.Project, environment)
+	variables := resolved.VariableValues()
+
+	u, err := url.Parse(resolved.URL)
+	if err != nil || u.Host == "" {
+		e.warn("Request %q: the url %s is not valid, it was not exported", request.Name, resolved.URL)
+		return nil, false
+	}
+
+	r := &HARRequest{
+		Method:      resolved.Method,
+		URL:         resolved.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*HARPair{},
+		Headers:     []*HARPair{},
+		QueryString: []*HARPair{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+
+	for _, header := range resolved.Headers {
+		r.Headers = append(r.Headers, &HARPair{Name: header.Key, Value: header.Value})
+
+		if strings.EqualFold(header.Key, "Cookie") {
+			r.Cookies = append(r.Cookies, harCookies(header.Value)...)
+		}
+	}
+
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		param := &HARPair{}
+		param.Name, _ = url.QueryUnescape(kv[0])
+		if len(kv) > 1 {
+			param.Value, _ = url.QueryUnescape(kv[1])
+		}
+
+		r.QueryString = append(r.QueryString, param)
+	}
+
+	r.PostData = e.harPostData(request, variables)
+	if r.PostData != nil {
+		r.BodySize = len(r.PostData.Text)
+
+		if !hasHARHeader(r.Headers, "Content-Type") && r.PostData.MimeType != "" {
+			r.Headers = append(r.Headers, &HARPair{Name: "Content-Type", Value: r.PostData.MimeType})
+		}
+	}
+
+	entry := &HAREntry{
+		StartedDateTime: eventTime(request.Created).Format(time.RFC3339),
+		Request:         r,
+		Response:        harResponse(response),
+		Timings:         &HARTimings{},
+		Comment:         request.Name,
+	}
+
+	if response != nil {
+		entry.StartedDateTime = eventTime(response.Created).Format(time.RFC3339)
+	}
+
+	return entry, resolved.Auth.Type != enums.AuthTypeNone
+}
+
+// harPostData converts the body of a request with the references to variables replaced
+func (e *exporter) harPostData(request *store.Request, variables map[string]string) *HARPostData {
+	v := func(s string) string {
+		return requestutils.ReplaceVariables(s, variables)
+	}
+
+	switch request.BodyMode {
+	case enums.BodyModeURLEncoded:
+		postData := &HARPostData{MimeType: "application/x-www-form-urlencoded", Params: []*HARPair{}}
+		pairs := []string{}
+
+		for _, param := range request.FormParams {
+			if param != nil && param.Enabled && param.Key != "" {
+				postData.Params = append(postData.Params, &HARPair{Name: v(param.Key), Value: v(param.Value)})
+				pairs = append(pairs, url.QueryEscape(v(param.Key))+"="+url.QueryEscape(v(param.Value)))
+			}
+		}
+
+		postData.Text = strings.Join(pairs, "&")
+
+		return postData
+
+	case enums.BodyModeFormData:
+		postData := &HARPostData{MimeType: "multipart/form-data", Params: []*HARPair{}}
+
+		for _, part := range request.MultipartParts {
+			if part == nil || !part.Enabled || part.Key == "" {
+				continue
+			}
+
+			if part.Type == enums.MultipartPartTypeFile {
+				e.warn("Request %q: the file of the form field %q was not exported", request.Name, part.Key)
+				postData.Params = append(postData.Params, &HARPair{Name: v(part.Key), FileName: part.Key, ContentType: part.ContentType})
+				continue
+			}
+
+			postData.Params = append(postData.Params, &HARPair{Name: v(part.Key), Value: v(part.Value), ContentType: part.ContentType})
+		}
+
+		return postData
+
+	case enums.BodyModeBinary:
+		e.warn("Request %q: the file of the body was not exported", request.Name)
+		return nil
+
+	case enums.BodyModeNone:
+		return nil
+	}
+
+	// the raw and GraphQL bodies do not need files
+	body, contentType, err := requestutils.BuildBody(request, nil)
+	if err != nil {
+		e.warn("Request %q: the body was not exported: %v", request.Name, err)
+		return nil
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	return &HARPostData{MimeType: contentType, Params: []*HARPair{}, Text: v(string(body))}
+}
+
+// harResponse converts a saved response, the requests without responses have an empty response
+func harResponse(response *store.Response) *HARResponse {
+	r := &HARResponse{
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*HARPair{},
+		Headers:     []*HARPair{},
+		Content:     &HARBody{},
+		HeadersSize: -1,
+	}
+
+	if response == nil {
+		return r
+	}
+
+	r.Status = response.StatusCode
+	r.StatusText = http.StatusText(response.StatusCode)
+	r.Content.Text = response.Body
+	r.Content.Size = len(response.Body)
+	r.BodySize = len(response.Body)
+
+	for _, header := range response.Headers {
+		if header.Key == "" {
+			continue
+		}
+
+		r.Headers = append(r.Headers, &HARPair{Name: header.Key, Value: header.Value})
+
+		switch strings.ToLower(header.Key) {
+		case "content-type":
+			r.Content.MimeType = header.Value
+		case "location":
+			r.RedirectURL = header.Value
+		case "set-cookie":
+			if cookies := harCookies(strings.SplitN(header.Value, ";", 2)[0]); len(cookies) > 0 {
+				r.Cookies = append(r.Cookies, cookies[0])
+			}
+		}
+	}
+
+	return r
+}
+
+// harCookies returns the cookies of a Cookie header
+func harCookies(header string) []*HARPair {
+	cookies := []*HARPair{}
+
+	for _, cookie := range strings.Split(header, ";") {
+		cookie = strings.TrimSpace(cookie)
+		if cookie == "" {
+			continue
+		}
+
+		kv := strings.SplitN(cookie, "=", 2)
+		pair := &HARPair{Name: kv[0]}
+		if len(kv) > 1 {
+			pair.Value = kv[1]
+		}
+
+		cookies = append(cookies, pair)
+	}
+
+	return cookies
+}
+
+// hasHARHeader checks if a header is in a list of headers
+func hasHARHeader(headers []*HARPair, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eventTime returns the time of an event, or the zero time if there is no event
+func eventTime(event *store.Event) time.Time {
+	if event == nil {
+		return time.Time{}
+	}
+
+	return event.At
+}
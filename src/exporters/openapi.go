@@ -0,0 +1,747 @@
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// openapiVersion is the version of the exported documents
+const openapiVersion = "3.1.0"
+
+// templateRegexp matches the references to variables like {{name}}
+var templateRegexp = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// OpenAPIDocument is an OpenAPI 3.1 document
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       *OpenAPIInfo                            `json:"info"`
+	Servers    []*OpenAPIServer                        `json:"servers,omitempty"`
+	Tags       []*OpenAPITag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIInfo is the information of an OpenAPI document
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIServer is a server of an OpenAPI document, its url can contain variables like {name}
+type OpenAPIServer struct {
+	URL         string                            `json:"url"`
+	Description string                            `json:"description,omitempty"`
+	Variables   map[string]*OpenAPIServerVariable `json:"variables,omitempty"`
+}
+
+// OpenAPIServerVariable is a variable of the url of a server
+type OpenAPIServerVariable struct {
+	Default string `json:"default"`
+}
+
+// OpenAPITag is a tag of the operations, each folder is a tag
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+// OpenAPIOperation is an operation of a path, each request is an operation
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Servers     []*OpenAPIServer            `json:"servers,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses,omitempty"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+// OpenAPIParameter is a path, query or header parameter of an operation
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required"`
+	Schema      *OpenAPISchema `json:"schema"`
+	Example     interface{}    `json:"example,omitempty"`
+}
+
+// OpenAPIRequestBody is the body of an operation, by content type
+type OpenAPIRequestBody struct {
+	Content map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse is a response of an operation
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Headers     map[string]*OpenAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIHeader is a header of a response
+type OpenAPIHeader struct {
+	Schema  *OpenAPISchema `json:"schema"`
+	Example string         `json:"example,omitempty"`
+}
+
+// OpenAPIMediaType is the content of a body with one or many examples
+type OpenAPIMediaType struct {
+	Schema   *OpenAPISchema             `json:"schema,omitempty"`
+	Example  interface{}                `json:"example,omitempty"`
+	Examples map[string]*OpenAPIExample `json:"examples,omitempty"`
+}
+
+// OpenAPIExample is a named example
+type OpenAPIExample struct {
+	Summary string      `json:"summary,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+// OpenAPISchema is a JSON schema
+type OpenAPISchema struct {
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Properties map[string]*OpenAPISchema `json:"properties,omitempty"`
+}
+
+// OpenAPIComponents contains the security schemes of the document
+type OpenAPIComponents struct {
+	SecuritySchemes map[string]*OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// OpenAPISecurityScheme is a security scheme, each auth of the requests is a scheme
+type OpenAPISecurityScheme struct {
+	Type   string             `json:"type"`
+	Scheme string             `json:"scheme,omitempty"`
+	Name   string             `json:"name,omitempty"`
+	In     string             `json:"in,omitempty"`
+	Flows  *OpenAPIOAuthFlows `json:"flows,omitempty"`
+}
+
+// OpenAPIOAuthFlows contains the OAuth2 flow of a security scheme
+type OpenAPIOAuthFlows struct {
+	ClientCredentials *OpenAPIOAuthFlow `json:"clientCredentials,omitempty"`
+	Password          *OpenAPIOAuthFlow `json:"password,omitempty"`
+}
+
+// OpenAPIOAuthFlow is an OAuth2 flow
+type OpenAPIOAuthFlow struct {
+	TokenURL string            `json:"tokenUrl"`
+	Scopes   map[string]string `json:"scopes"`
+}
+
+// OpenAPIExport contains a project exported to OpenAPI
+type OpenAPIExport struct {
+	Document *OpenAPIDocument
+	Warnings []string
+}
+
+// openapiExporter contains the state of an export
+type openapiExporter struct {
+	*exporter
+	doc          *OpenAPIDocument
+	servers      map[*OpenAPIOperation]string // part of the urls before the path of the operations
+	operationIDs map[string]bool
+	schemes      map[string]string // names of the security schemes by their settings
+	responses    map[string][]*store.Response
+}
+
+// ExportOpenAPI converts the requests of a project into an OpenAPI 3.1 document. The paths come from the
+// urls of the requests and their variables are path params, the folders are tags, and the examples come
+// from the bodies of the requests and their saved responses.
+func ExportOpenAPI(project *Project) *OpenAPIExport {
+	o := &openapiExporter{
+		exporter: newExporter(project),
+		doc: &OpenAPIDocument{
+			OpenAPI: openapiVersion,
+			Info:    &OpenAPIInfo{Title: project.Project.Name, Version: "1.0.0"},
+			Tags:    []*OpenAPITag{},
+			Paths:   map[string]map[string]*OpenAPIOperation{},
+		},
+		servers:      map[*OpenAPIOperation]string{},
+		operationIDs: map[string]bool{},
+		schemes:      map[string]string{},
+		responses:    map[string][]*store.Response{},
+	}
+
+	for _, response := range project.Responses {
+		o.responses[response.RequestID] = append(o.responses[response.RequestID], response)
+	}
+
+	o.exportFolder("", nil)
+	o.setServers()
+
+	return &OpenAPIExport{
+		Document: o.doc,
+		Warnings: o.warnings,
+	}
+}
+
+// exportFolder adds the tags and operations of the subfolders and requests of a folder
+func (o *openapiExporter) exportFolder(folderID string, path []string) {
+	for _, folder := range o.children[folderID] {
+		folderPath := append(append([]string{}, path...), folder.Name)
+
+		o.doc.Tags = append(o.doc.Tags, &OpenAPITag{Name: strings.Join(folderPath, " / ")})
+		o.exportFolder(folder.ID, folderPath)
+	}
+
+	for _, request := range o.requests[folderID] {
+		o.exportRequest(request, strings.Join(path, " / "))
+	}
+}
+
+// exportRequest adds the operation of a request
+func (o *openapiExporter) exportRequest(request *store.Request, tag string) {
+	folders := o.folderChain(request)
+	where := fmt.Sprintf("Request %q", request.Name)
+
+	request = requestutils.WithDefaults(request, folders, o.project.Project)
+
+	if strings.TrimSpace(request.URL) == "" {
+		o.warn("%s: the request does not have a url, it was not exported", where)
+		return
+	}
+
+	server, rawPath := splitServer(request.URL)
+	path := templateRegexp.ReplaceAllString(rawPath, "{$1}")
+	method := strings.ToLower(request.Type)
+
+	if o.doc.Paths[path] == nil {
+		o.doc.Paths[path] = map[string]*OpenAPIOperation{}
+	}
+
+	if o.doc.Paths[path][method] != nil {
+		o.warn("%s: there is another request with the method %s and the path %s, it was not exported", where, request.Type, path)
+		return
+	}
+
+	operation := &OpenAPIOperation{
+		OperationID: o.operationID(request.Name),
+		Summary:     request.Name,
+	}
+
+	if tag != "" {
+		operation.Tags = []string{tag}
+	}
+
+	o.servers[operation] = server
+
+	// parameters, the variables of the path are path params
+	pathParams := map[string]bool{}
+
+	for _, match := range templateRegexp.FindAllStringSubmatch(rawPath, -1) {
+		if pathParams[match[1]] {
+			continue
+		}
+
+		pathParams[match[1]] = true
+
+		operation.Parameters = append(operation.Parameters, &OpenAPIParameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &OpenAPISchema{Type: "string"},
+			Example:  o.variableValue(match[1]),
+		})
+	}
+
+	for _, param := range request.QueryParams {
+		if param != nil && param.Key != "" {
+			operation.Parameters = append(operation.Parameters, openapiParameter(param, "query"))
+		}
+	}
+
+	for _, header := range request.Headers {
+		if header == nil {
+			continue
+		}
+
+		// the content type, accept and authorization headers are defined by the body and the auth
+		switch strings.ToLower(header.Key) {
+		case "", "content-type", "accept", "authorization":
+			continue
+		}
+
+		operation.Parameters = append(operation.Parameters, openapiParameter(header, "header"))
+	}
+
+	operation.RequestBody = openapiRequestBody(request)
+	operation.Responses = o.openapiResponses(request.ID)
+
+	// security
+	auths := []*store.Auth{request.Auth}
+	for _, folder := range folders {
+		auths = append(auths, folder.Auth)
+	}
+	auths = append(auths, o.project.Project.Auth)
+
+	operation.Security = o.security(where, requestutils.ResolveAuth(auths...))
+
+	o.doc.Paths[path][method] = operation
+}
+
+// setServers sets the servers used by most operations as the servers of the document,
+// the other operations have their own servers
+func (o *openapiExporter) setServers() {
+	count := map[string]int{}
+	common := ""
+
+	for _, server := range o.servers {
+		count[server]++
+	}
+
+	for server, n := range count {
+		if n > count[common] || (n == count[common] && server < common) {
+			common = server
+		}
+	}
+
+	if common != "" {
+		o.doc.Servers = o.openapiServers(common)
+	}
+
+	for operation, server := range o.servers {
+		if server != common {
+			operation.Servers = o.openapiServers(server)
+		}
+	}
+}
+
+// openapiServers returns the servers of the part of the urls before the path. When it is a variable
+// like {{baseUrl}}, there is a server for each environment that has the variable.
+func (o *openapiExporter) openapiServers(server string) []*OpenAPIServer {
+	// the urls without server are relative to the document
+	if server == "" {
+		return []*OpenAPIServer{{URL: "/"}}
+	}
+
+	servers := []*OpenAPIServer{}
+
+	if match := templateRegexp.FindStringSubmatch(server); match != nil && match[0] == server {
+		for _, environment := range o.project.Environments {
+			if value, ok := environment.Variables[match[1]]; ok && value != "" {
+				servers = append(servers, &OpenAPIServer{URL: value, Description: environment.Name})
+			}
+		}
+
+		if len(servers) > 0 {
+			return servers
+		}
+	}
+
+	// the variables of the url are variables of the server, with their values in the project as defaults
+	openapiServer := &OpenAPIServer{URL: templateRegexp.ReplaceAllString(server, "{$1}")}
+
+	for _, match := range templateRegexp.FindAllStringSubmatch(server, -1) {
+		if openapiServer.Variables == nil {
+			openapiServer.Variables = map[string]*OpenAPIServerVariable{}
+		}
+
+		openapiServer.Variables[match[1]] = &OpenAPIServerVariable{Default: o.variableValue(match[1])}
+	}
+
+	return append(servers, openapiServer)
+}
+
+// openapiResponses returns the saved responses of a request, by status code
+func (o *openapiExporter) openapiResponses(requestID string) map[string]*OpenAPIResponse {
+	saved := o.responses[requestID]
+	if len(saved) == 0 {
+		return nil
+	}
+
+	byStatus := map[int][]*store.Response{}
+	for _, response := range saved {
+		byStatus[response.StatusCode] = append(byStatus[response.StatusCode], response)
+	}
+
+	responses := map[string]*OpenAPIResponse{}
+
+	for status, list := range byStatus {
+		response := &OpenAPIResponse{Description: http.StatusText(status)}
+
+		if len(list) == 1 {
+			response.Description = list[0].Name
+		}
+
+		if response.Description == "" {
+			response.Description = "Response " + strconv.Itoa(status)
+		}
+
+		for _, saved := range list {
+			contentType := ""
+
+			for _, header := range saved.Headers {
+				if header.Key == "" {
+					continue
+				}
+
+				if strings.EqualFold(header.Key, "Content-Type") {
+					contentType = header.Value
+					continue
+				}
+
+				if response.Headers == nil {
+					response.Headers = map[string]*OpenAPIHeader{}
+				}
+
+				if response.Headers[header.Key] == nil {
+					response.Headers[header.Key] = &OpenAPIHeader{Schema: &OpenAPISchema{Type: "string"}, Example: header.Value}
+				}
+			}
+
+			if saved.Body == "" {
+				continue
+			}
+
+			contentType, example := bodyExample(contentType, saved.Body)
+
+			if response.Content == nil {
+				response.Content = map[string]*OpenAPIMediaType{}
+			}
+
+			media := response.Content[contentType]
+			if media == nil {
+				media = &OpenAPIMediaType{}
+				response.Content[contentType] = media
+			}
+
+			// a single example is the example of the content, many examples are named after the responses
+			if len(list) == 1 {
+				media.Example = example
+				continue
+			}
+
+			if media.Examples == nil {
+				media.Examples = map[string]*OpenAPIExample{}
+			}
+
+			name := exampleName(saved.Name)
+			for j := 2; media.Examples[name] != nil; j++ {
+				name = exampleName(saved.Name) + strconv.Itoa(j)
+			}
+
+			media.Examples[name] = &OpenAPIExample{Summary: saved.Name, Value: example}
+		}
+
+		responses[strconv.Itoa(status)] = response
+	}
+
+	return responses
+}
+
+// security returns the security requirement of an auth, and adds its scheme to the document
+func (o *openapiExporter) security(where string, auth *store.Auth) []map[string][]string {
+	scheme := &OpenAPISecurityScheme{}
+	name := ""
+	scopes := []string{}
+
+	switch {
+	case auth.Type == enums.AuthTypeBasic:
+		scheme.Type, scheme.Scheme, name = "http", "basic", "basicAuth"
+
+	case auth.Type == enums.AuthTypeBearer:
+		scheme.Type, scheme.Scheme, name = "http", "bearer", "bearerAuth"
+
+	case auth.Type == enums.AuthTypeDigest:
+		scheme.Type, scheme.Scheme, name = "http", "digest", "digestAuth"
+
+	case auth.Type == enums.AuthTypeAPIKey && auth.APIKey != nil:
+		scheme.Type, scheme.Name, name = "apiKey", auth.APIKey.Key, "apiKey"
+
+		scheme.In = "header"
+		if auth.APIKey.In == enums.APIKeyInQuery {
+			scheme.In = "query"
+		}
+
+	case auth.Type == enums.AuthTypeOAuth2 && auth.OAuth2 != nil:
+		scheme.Type, name = "oauth2", "oauth2"
+		scopes = strings.Fields(auth.OAuth2.Scope)
+
+		flow := &OpenAPIOAuthFlow{TokenURL: auth.OAuth2.TokenURL, Scopes: map[string]string{}}
+		for _, scope := range scopes {
+			flow.Scopes[scope] = ""
+		}
+
+		scheme.Flows = &OpenAPIOAuthFlows{}
+		if auth.OAuth2.GrantType == enums.OAuth2GrantPassword {
+			scheme.Flows.Password = flow
+		} else {
+			scheme.Flows.ClientCredentials = flow
+		}
+
+	case auth.Type == enums.AuthTypeAWSV4:
+		o.warn("%s: the AWS Signature auth is not supported by OpenAPI, it was not exported", where)
+		return nil
+
+	default:
+		return nil
+	}
+
+	// the same scheme is used by all the requests with the same settings, the oauth2
+	// scopes are part of the requirement, so the schemes are compared without them
+	key := strings.Join([]string{scheme.Type, scheme.Scheme, scheme.Name, scheme.In}, "|")
+	if auth.OAuth2 != nil && scheme.Flows != nil {
+		key += "|" + auth.OAuth2.GrantType + "|" + auth.OAuth2.TokenURL
+	}
+
+	if o.schemes[key] == "" {
+		if o.doc.Components == nil {
+			o.doc.Components = &OpenAPIComponents{SecuritySchemes: map[string]*OpenAPISecurityScheme{}}
+		}
+
+		base := name
+		for j := 2; o.doc.Components.SecuritySchemes[name] != nil; j++ {
+			name = base + strconv.Itoa(j)
+		}
+
+		o.schemes[key] = name
+		o.doc.Components.SecuritySchemes[name] = scheme
+	} else if scheme.Flows != nil {
+		// the scopes of the flow are all the scopes used by the requests
+		flows := o.doc.Components.SecuritySchemes[o.schemes[key]].Flows
+
+		for _, flow := range []*OpenAPIOAuthFlow{flows.ClientCredentials, flows.Password} {
+			if flow != nil {
+				for _, scope := range scopes {
+					flow.Scopes[scope] = ""
+				}
+			}
+		}
+	}
+
+	return []map[string][]string{{o.schemes[key]: scopes}}
+}
+
+// operationID returns a unique operation id in camel case for a request name
+func (o *openapiExporter) operationID(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	id := ""
+	for j, word := range words {
+		if j == 0 {
+			id += strings.ToLower(word[:1]) + word[1:]
+		} else {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	if id == "" {
+		id = "operation"
+	}
+
+	unique := id
+	for j := 2; o.operationIDs[unique]; j++ {
+		unique = id + strconv.Itoa(j)
+	}
+
+	o.operationIDs[unique] = true
+
+	return unique
+}
+
+// variableValue returns the value of a variable in the project, or in the first environment that has it
+func (o *openapiExporter) variableValue(name string) string {
+	if value, ok := o.project.Project.Variables[name]; ok {
+		return value
+	}
+
+	for _, environment := range o.project.Environments {
+		if value, ok := environment.Variables[name]; ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// openapiParameter converts a query param or a header, the enabled params are required
+func openapiParameter(param *store.Param, in string) *OpenAPIParameter {
+	return &OpenAPIParameter{
+		Name:        param.Key,
+		In:          in,
+		Description: param.Description,
+		Required:    param.Enabled,
+		Schema:      &OpenAPISchema{Type: "string"},
+		Example:     param.Value,
+	}
+}
+
+// openapiRequestBody returns the body of a request with its content as example
+func openapiRequestBody(request *store.Request) *OpenAPIRequestBody {
+	switch request.BodyMode {
+	case enums.BodyModeRaw:
+		if request.Body == "" {
+			return nil
+		}
+
+		contentType, example := bodyExample(request.BodyContentType, request.Body)
+
+		return &OpenAPIRequestBody{Content: map[string]*OpenAPIMediaType{
+			contentType: {Example: example},
+		}}
+
+	case enums.BodyModeURLEncoded:
+		schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+		example := map[string]string{}
+
+		for _, param := range request.FormParams {
+			if param == nil || param.Key == "" {
+				continue
+			}
+
+			schema.Properties[param.Key] = &OpenAPISchema{Type: "string"}
+
+			if param.Enabled {
+				example[param.Key] = param.Value
+			}
+		}
+
+		return &OpenAPIRequestBody{Content: map[string]*OpenAPIMediaType{
+			"application/x-www-form-urlencoded": {Schema: schema, Example: example},
+		}}
+
+	case enums.BodyModeFormData:
+		schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+		example := map[string]string{}
+
+		for _, part := range request.MultipartParts {
+			if part == nil || part.Key == "" {
+				continue
+			}
+
+			if part.Type == enums.MultipartPartTypeFile {
+				schema.Properties[part.Key] = &OpenAPISchema{Type: "string", Format: "binary"}
+				continue
+			}
+
+			schema.Properties[part.Key] = &OpenAPISchema{Type: "string"}
+
+			if part.Enabled {
+				example[part.Key] = part.Value
+			}
+		}
+
+		return &OpenAPIRequestBody{Content: map[string]*OpenAPIMediaType{
+			"multipart/form-data": {Schema: schema, Example: example},
+		}}
+
+	case enums.BodyModeBinary:
+		contentType := request.BodyContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		return &OpenAPIRequestBody{Content: map[string]*OpenAPIMediaType{
+			contentType: {Schema: &OpenAPISchema{Type: "string", Format: "binary"}},
+		}}
+
+	case enums.BodyModeGraphQL:
+		if request.GraphQL == nil {
+			return nil
+		}
+
+		example := map[string]interface{}{"query": request.GraphQL.Query}
+
+		var variables interface{}
+		if json.Unmarshal([]byte(request.GraphQL.Variables), &variables) == nil && variables != nil {
+			example["variables"] = variables
+		}
+
+		return &OpenAPIRequestBody{Content: map[string]*OpenAPIMediaType{
+			"application/json": {Example: example},
+		}}
+	}
+
+	return nil
+}
+
+// bodyExample returns the content type of a body and its example, the JSON bodies are decoded
+func bodyExample(contentType, body string) (string, interface{}) {
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+
+	if contentType == "" {
+		contentType = "text/plain"
+		if json.Valid([]byte(body)) {
+			contentType = "application/json"
+		}
+	}
+
+	if strings.HasSuffix(contentType, "json") {
+		var value interface{}
+		if json.Unmarshal([]byte(body), &value) == nil {
+			return contentType, value
+		}
+	}
+
+	return contentType, body
+}
+
+// exampleName returns the name of an example, made of the letters and digits of a response name
+func exampleName(name string) string {
+	example := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(name))
+
+	if example == "" {
+		return "example"
+	}
+
+	return example
+}
+
+// splitServer splits a url into the part before the path, which is a variable like {{baseUrl}},
+// a scheme with a host or a host, and the path without the query string and fragment
+func splitServer(rawURL string) (string, string) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+
+	server := ""
+
+	switch {
+	case strings.HasPrefix(rawURL, "{{") && strings.Contains(rawURL, "}}"):
+		end := strings.Index(rawURL, "}}") + 2
+		server, rawURL = rawURL[:end], rawURL[end:]
+
+	case strings.Contains(rawURL, "://"):
+		start := strings.Index(rawURL, "://") + 3
+		end := strings.Index(rawURL[start:], "/")
+		if end < 0 {
+			server, rawURL = rawURL, ""
+		} else {
+			server, rawURL = rawURL[:start+end], rawURL[start+end:]
+		}
+
+	case !strings.HasPrefix(rawURL, "/"):
+		end := strings.Index(rawURL, "/")
+		if end < 0 {
+			server, rawURL = rawURL, ""
+		} else {
+			server, rawURL = rawURL[:end], rawURL[end:]
+		}
+	}
+
+	if !strings.HasPrefix(rawURL, "/") {
+		rawURL = "/" + rawURL
+	}
+
+	return server, rawURL
+}
@@ -0,0 +1,394 @@
+package exporters
+
+import (
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// postmanSchema is the schema of the exported collections
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection is a Postman collection v2.1
+type PostmanCollection struct {
+	Info     *PostmanInfo       `json:"info"`
+	Item     []*PostmanItem     `json:"item"`
+	Variable []*PostmanVariable `json:"variable,omitempty"`
+	Auth     *PostmanAuth       `json:"auth,omitempty"`
+}
+
+// PostmanInfo is the information of a Postman collection
+type PostmanInfo struct {
+	PostmanID string `json:"_postman_id"`
+	Name      string `json:"name"`
+	Schema    string `json:"schema"`
+}
+
+// PostmanItem is a folder (with items) or a request of a Postman collection
+type PostmanItem struct {
+	Name    string          `json:"name"`
+	Item    []*PostmanItem  `json:"item,omitempty"`
+	Request *PostmanRequest `json:"request,omitempty"`
+	Auth    *PostmanAuth    `json:"auth,omitempty"`
+}
+
+// PostmanRequest is a request of a Postman collection
+type PostmanRequest struct {
+	Method string         `json:"method"`
+	Header []*PostmanPair `json:"header"`
+	Body   *PostmanBody   `json:"body,omitempty"`
+	URL    *PostmanURL    `json:"url"`
+	Auth   *PostmanAuth   `json:"auth,omitempty"`
+}
+
+// PostmanURL is the url of a request of a Postman collection
+type PostmanURL struct {
+	Raw   string         `json:"raw"`
+	Query []*PostmanPair `json:"query,omitempty"`
+}
+
+// PostmanPair is a header, query param or form param of a Postman collection
+type PostmanPair struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Description string `json:"description,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// PostmanBody is the body of a request of a Postman collection
+type PostmanBody struct {
+	Mode       string              `json:"mode"`
+	Raw        string              `json:"raw,omitempty"`
+	URLEncoded []*PostmanPair      `json:"urlencoded,omitempty"`
+	FormData   []*PostmanPair      `json:"formdata,omitempty"`
+	GraphQL    *PostmanGraphQL     `json:"graphql,omitempty"`
+	Options    *PostmanBodyOptions `json:"options,omitempty"`
+}
+
+// PostmanGraphQL is the body of a GraphQL request of a Postman collection
+type PostmanGraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables"`
+}
+
+// PostmanBodyOptions contains the language of a raw body of a Postman collection
+type PostmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+// PostmanAuth is the auth of a Postman collection, folder or request
+type PostmanAuth struct {
+	Type   string                `json:"type"`
+	Basic  []*PostmanAuthSetting `json:"basic,omitempty"`
+	Bearer []*PostmanAuthSetting `json:"bearer,omitempty"`
+	APIKey []*PostmanAuthSetting `json:"apikey,omitempty"`
+	Digest []*PostmanAuthSetting `json:"digest,omitempty"`
+	AWSV4  []*PostmanAuthSetting `json:"awsv4,omitempty"`
+	OAuth2 []*PostmanAuthSetting `json:"oauth2,omitempty"`
+}
+
+// PostmanAuthSetting is a setting of an auth of a Postman collection
+type PostmanAuthSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// PostmanVariable is a variable of a Postman collection or environment
+type PostmanVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Enabled bool   `json:"enabled,omitempty"`
+}
+
+// PostmanEnvironment is a Postman environment
+type PostmanEnvironment struct {
+	ID     string             `json:"id"`
+	Name   string             `json:"name"`
+	Values []*PostmanVariable `json:"values"`
+	Scope  string             `json:"_postman_variable_scope"`
+}
+
+// PostmanExport contains a project exported to Postman
+type PostmanExport struct {
+	Collection   *PostmanCollection
+	Environments []*PostmanEnvironment
+	Warnings     []string
+}
+
+// languages are the languages of the raw bodies of the content types
+var languages = map[string]string{
+	"application/json":       "json",
+	"application/xml":        "xml",
+	"text/html":              "html",
+	"application/javascript": "javascript",
+	"text/plain":             "text",
+}
+
+// ExportPostman converts a project into a Postman collection v2.1 and its environments. The base urls
+// and headers of the folders and the project are not supported by Postman, so they are applied to the requests.
+func ExportPostman(project *Project) *PostmanExport {
+	e := newExporter(project)
+
+	if project.Project.BaseURL != "" || len(project.Project.Headers) > 0 {
+		e.warn("Project %q: the base url and headers of the project were added to its requests", project.Project.Name)
+	}
+
+	collection := &PostmanCollection{
+		Info: &PostmanInfo{
+			PostmanID: project.Project.ID,
+			Name:      project.Project.Name,
+			Schema:    postmanSchema,
+		},
+		Item: e.postmanItems(""),
+		Auth: e.postmanAuth(project.Project.Auth),
+	}
+
+	for _, name := range sortedKeys(project.Project.Variables) {
+		collection.Variable = append(collection.Variable, &PostmanVariable{
+			Key:   name,
+			Value: project.Project.Variables[name],
+			Type:  "string",
+		})
+	}
+
+	environments := []*PostmanEnvironment{}
+
+	for _, environment := range project.Environments {
+		postmanEnvironment := &PostmanEnvironment{
+			ID:     environment.ID,
+			Name:   environment.Name,
+			Values: []*PostmanVariable{},
+			Scope:  "environment",
+		}
+
+		for _, name := range sortedKeys(environment.Variables) {
+			postmanEnvironment.Values = append(postmanEnvironment.Values, &PostmanVariable{
+				Key:     name,
+				Value:   environment.Variables[name],
+				Type:    "default",
+				Enabled: true,
+			})
+		}
+
+		environments = append(environments, postmanEnvironment)
+	}
+
+	return &PostmanExport{
+		Collection:   collection,
+		Environments: environments,
+		Warnings:     e.warnings,
+	}
+}
+
+// postmanItems returns the items of a folder, or of the root of the project
+func (e *exporter) postmanItems(folderID string) []*PostmanItem {
+	items := []*PostmanItem{}
+
+	for _, folder := range e.children[folderID] {
+		if len(folder.Variables) > 0 {
+			e.warn("Folder %q: the variables of the folder were not exported", folder.Name)
+		}
+
+		if folder.BaseURL != "" || len(folder.Headers) > 0 {
+			e.warn("Folder %q: the base url and headers of the folder were added to its requests", folder.Name)
+		}
+
+		items = append(items, &PostmanItem{
+			Name: folder.Name,
+			Item: e.postmanItems(folder.ID),
+			Auth: e.postmanAuth(folder.Auth),
+		})
+	}
+
+	for _, request := range e.requests[folderID] {
+		items = append(items, &PostmanItem{
+			Name:    request.Name,
+			Request: e.postmanRequest(request),
+		})
+	}
+
+	return items
+}
+
+// postmanRequest converts a request
+func (e *exporter) postmanRequest(request *store.Request) *PostmanRequest {
+	request = requestutils.WithDefaults(request, e.folderChain(request), e.project.Project)
+
+	r := &PostmanRequest{
+		Method: request.Type,
+		Header: postmanPairs(request.Headers),
+		URL: &PostmanURL{
+			Raw:   request.URL,
+			Query: postmanPairs(request.QueryParams),
+		},
+		Auth: e.postmanAuth(request.Auth),
+	}
+
+	switch request.BodyMode {
+	case enums.BodyModeRaw:
+		if request.Body == "" {
+			break
+		}
+
+		r.Body = &PostmanBody{Mode: "raw", Raw: request.Body}
+
+		if language, ok := languages[request.BodyContentType]; ok {
+			r.Body.Options = &PostmanBodyOptions{}
+			r.Body.Options.Raw.Language = language
+		} else if request.BodyContentType != "" && !hasHeader(request.Headers, "Content-Type") {
+			r.Header = append(r.Header, &PostmanPair{Key: "Content-Type", Value: request.BodyContentType})
+		}
+
+	case enums.BodyModeURLEncoded:
+		r.Body = &PostmanBody{Mode: "urlencoded", URLEncoded: postmanPairs(request.FormParams)}
+
+	case enums.BodyModeFormData:
+		r.Body = &PostmanBody{Mode: "formdata", FormData: []*PostmanPair{}}
+
+		for _, part := range request.MultipartParts {
+			if part == nil {
+				continue
+			}
+
+			pair := &PostmanPair{
+				Key:         part.Key,
+				Value:       part.Value,
+				Type:        "text",
+				Disabled:    !part.Enabled,
+				Description: part.Description,
+				ContentType: part.ContentType,
+			}
+
+			if part.Type == enums.MultipartPartTypeFile {
+				e.warn("Request %q: the file of the form field %q was not exported", request.Name, part.Key)
+				pair.Type = "file"
+				pair.Value = ""
+			}
+
+			r.Body.FormData = append(r.Body.FormData, pair)
+		}
+
+	case enums.BodyModeBinary:
+		e.warn("Request %q: the file of the body was not exported", request.Name)
+
+	case enums.BodyModeGraphQL:
+		r.Body = &PostmanBody{Mode: "graphql", GraphQL: &PostmanGraphQL{}}
+
+		if request.GraphQL != nil {
+			r.Body.GraphQL.Query = request.GraphQL.Query
+			r.Body.GraphQL.Variables = request.GraphQL.Variables
+		}
+	}
+
+	return r
+}
+
+// postmanAuth converts an auth, the empty auths inherit the auth of the parent
+func (e *exporter) postmanAuth(auth *store.Auth) *PostmanAuth {
+	if auth == nil {
+		return nil
+	}
+
+	settings := func(pairs ...string) []*PostmanAuthSetting {
+		list := []*PostmanAuthSetting{}
+		for i := 0; i < len(pairs); i += 2 {
+			list = append(list, &PostmanAuthSetting{Key: pairs[i], Value: pairs[i+1], Type: "string"})
+		}
+		return list
+	}
+
+	switch auth.Type {
+	case enums.AuthTypeNone:
+		return &PostmanAuth{Type: "noauth"}
+
+	case enums.AuthTypeBasic:
+		if auth.Basic != nil {
+			return &PostmanAuth{Type: "basic", Basic: settings("username", auth.Basic.Username, "password", auth.Basic.Password)}
+		}
+
+	case enums.AuthTypeBearer:
+		if auth.Bearer != nil {
+			return &PostmanAuth{Type: "bearer", Bearer: settings("token", auth.Bearer.Token)}
+		}
+
+	case enums.AuthTypeAPIKey:
+		if auth.APIKey != nil {
+			return &PostmanAuth{Type: "apikey", APIKey: settings("key", auth.APIKey.Key, "value", auth.APIKey.Value, "in", auth.APIKey.In)}
+		}
+
+	case enums.AuthTypeDigest:
+		if auth.Digest != nil {
+			return &PostmanAuth{Type: "digest", Digest: settings("username", auth.Digest.Username, "password", auth.Digest.Password)}
+		}
+
+	case enums.AuthTypeAWSV4:
+		if auth.AWSV4 != nil {
+			return &PostmanAuth{Type: "awsv4", AWSV4: settings(
+				"accessKey", auth.AWSV4.AccessKey,
+				"secretKey", auth.AWSV4.SecretKey,
+				"sessionToken", auth.AWSV4.SessionToken,
+				"region", auth.AWSV4.Region,
+				"service", auth.AWSV4.Service,
+			)}
+		}
+
+	case enums.AuthTypeOAuth2:
+		if auth.OAuth2 != nil {
+			grantType := auth.OAuth2.GrantType
+			if grantType == enums.OAuth2GrantPassword {
+				grantType = "password_credentials"
+			}
+
+			return &PostmanAuth{Type: "oauth2", OAuth2: settings(
+				"grant_type", grantType,
+				"accessTokenUrl", auth.OAuth2.TokenURL,
+				"clientId", auth.OAuth2.ClientID,
+				"clientSecret", auth.OAuth2.ClientSecret,
+				"username", auth.OAuth2.Username,
+				"password", auth.OAuth2.Password,
+				"scope", auth.OAuth2.Scope,
+			)}
+		}
+	}
+
+	return nil
+}
+
+// postmanPairs converts the headers, query params or form params
+func postmanPairs(params []*store.Param) []*PostmanPair {
+	pairs := []*PostmanPair{}
+
+	for _, param := range params {
+		if param == nil {
+			continue
+		}
+
+		pairs = append(pairs, &PostmanPair{
+			Key:         param.Key,
+			Value:       param.Value,
+			Disabled:    !param.Enabled,
+			Description: param.Description,
+		})
+	}
+
+	return pairs
+}
+
+// hasHeader checks if a header is in a list of headers
+func hasHeader(headers []*store.Param, key string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Key, key) {
+			return true
+		}
+	}
+
+	return false
+}
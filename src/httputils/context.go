@@ -2,7 +2,9 @@ package httputils
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"strings"
 
 	"apiboy/backend/src/authutils"
 
@@ -39,3 +41,28 @@ func SetContextAuthData(ctx context.Context, d *authutils.AuthData) context.Cont
 func GetContextAuthData(ctx context.Context) *authutils.AuthData {
 	return ctx.Value(ContextKeyAuthData).(*authutils.AuthData)
 }
+
+// GetContextClientIP gets the ip of the client that sent the request in the context. Each proxy appends
+// the address that it received the request from to the forwarded header, so the client is the address
+// added by the last trusted proxy, the previous ones can be set by the client. When the header doesn't
+// have an address for each trusted proxy, the address of the connection is used.
+func GetContextClientIP(ctx context.Context, trustedProxies int) string {
+	r := GetContextRequest(ctx)
+
+	if forwardedFor := r.Header["X-Forwarded-For"]; len(forwardedFor) > 0 && trustedProxies > 0 {
+		addresses := strings.Split(strings.Join(forwardedFor, ","), ",")
+
+		if len(addresses) >= trustedProxies {
+			if ip := strings.TrimSpace(addresses[len(addresses)-trustedProxies]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
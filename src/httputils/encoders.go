@@ -38,9 +38,16 @@ func ErrorEncoder(log *logger.Logger) kithttp.ErrorEncoder {
 
 		w.WriteHeader(statusCodeForError(err))
 
-		json.NewEncoder(w).Encode(map[string]interface{}{
+		res := map[string]interface{}{
 			"error": msg,
-		})
+		}
+
+		// include the server copy so the client can merge its changes
+		if e, ok := err.(errors.Conflict); ok {
+			res["current"] = e.Current
+		}
+
+		json.NewEncoder(w).Encode(res)
 	}
 }
 
@@ -57,6 +64,8 @@ func statusCodeForError(err error) int {
 		return http.StatusBadRequest
 	case errors.BadRequest:
 		return http.StatusBadRequest
+	case errors.Conflict:
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
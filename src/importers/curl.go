@@ -0,0 +1,493 @@
+package importers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// curlOptionsWithValue are the options of curl that take a value, the short options can
+// include their value like -XPOST
+var curlOptionsWithValue = stringSet(
+	"-X", "--request", "-H", "--header",
+	"-d", "--data", "--data-raw", "--data-ascii", "--data-binary", "--data-urlencode",
+	"-F", "--form", "--form-string", "-u", "--user", "-b", "--cookie",
+	"-A", "--user-agent", "-e", "--referer", "--url", "-T", "--upload-file",
+	"-o", "--output", "-m", "--max-time", "--connect-timeout", "-x", "--proxy",
+	"-w", "--write-out", "-c", "--cookie-jar", "--cacert", "--cert", "--key", "--resolve",
+)
+
+// curlIgnoredOptions are the options of curl that do not change the request
+var curlIgnoredOptions = stringSet(
+	"-s", "--silent", "-S", "--show-error", "-v", "--verbose", "-i", "--include",
+	"-L", "--location", "-k", "--insecure", "--compressed", "-f", "--fail", "-#", "--progress-bar",
+	"--http1.1", "--http2", "-o", "--output", "-m", "--max-time", "--connect-timeout",
+	"-w", "--write-out", "-c", "--cookie-jar",
+)
+
+// ParseCurl converts a curl command line into a request named after its method and path, without
+// ids or folder. It also returns warnings about the options that could not be converted, like the files of the body.
+func ParseCurl(command string) (*store.Request, []string, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid curl command: %v", err)
+	}
+
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, nil, fmt.Errorf("invalid curl command: it must start with curl")
+	}
+
+	r := &Result{Warnings: []string{}}
+	request := &store.Request{
+		Type:        enums.RequestTypeGet,
+		BodyMode:    enums.BodyModeNone,
+		QueryParams: []*store.Param{},
+		Headers:     []*store.Param{},
+	}
+
+	method := ""
+	data := []string{}
+	form := []*store.MultipartPart{}
+	get := false
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+
+		// the option and its value, which can be in the same argument
+		if strings.HasPrefix(arg, "--") && strings.Contains(arg, "=") && curlOptionsWithValue[arg[:strings.Index(arg, "=")]] {
+			arg, value = arg[:strings.Index(arg, "=")], arg[strings.Index(arg, "=")+1:]
+		} else if curlOptionsWithValue[arg] {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("invalid curl command: missing value of %s", arg)
+			}
+
+			i++
+			value = args[i]
+		} else if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && curlOptionsWithValue[arg[:2]] {
+			arg, value = arg[:2], arg[2:]
+		} else if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' {
+			// combined short flags like -sSL
+			for _, flag := range arg[1:] {
+				if !curlIgnoredOptions["-"+string(flag)] {
+					applyCurlFlag(r, "-"+string(flag), &method, &get)
+				}
+			}
+			continue
+		}
+
+		switch arg {
+		case "-X", "--request":
+			method = value
+
+		case "-H", "--header":
+			key, headerValue := value, ""
+			if j := strings.Index(value, ":"); j >= 0 {
+				key, headerValue = strings.TrimSpace(value[:j]), strings.TrimSpace(value[j+1:])
+			}
+
+			request.Headers = append(request.Headers, &store.Param{Key: key, Value: headerValue, Enabled: true})
+
+		case "-d", "--data", "--data-ascii", "--data-raw", "--data-binary":
+			if strings.HasPrefix(value, "@") && arg != "--data-raw" {
+				r.warn("The file %s of the body was not imported", value[1:])
+				continue
+			}
+
+			// curl removes the line breaks of the data, except with --data-binary
+			if arg != "--data-binary" && arg != "--data-raw" {
+				value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+			}
+
+			data = append(data, value)
+
+		case "--data-urlencode":
+			data = append(data, curlURLEncode(value))
+
+		case "-F", "--form", "--form-string":
+			part := &store.MultipartPart{Type: enums.MultipartPartTypeText, Enabled: true}
+			part.Key = value
+			if j := strings.Index(value, "="); j >= 0 {
+				part.Key, part.Value = value[:j], value[j+1:]
+			}
+
+			if arg != "--form-string" && (strings.HasPrefix(part.Value, "@") || strings.HasPrefix(part.Value, "<")) {
+				r.warn("The file of the form field %q was not imported", part.Key)
+				continue
+			}
+
+			// the content type of the part is set with ;type=
+			if j := strings.Index(part.Value, ";type="); j >= 0 && arg != "--form-string" {
+				part.Value, part.ContentType = part.Value[:j], part.Value[j+len(";type="):]
+			}
+
+			form = append(form, part)
+
+		case "-u", "--user":
+			auth := &store.BasicAuth{Username: value}
+			if j := strings.Index(value, ":"); j >= 0 {
+				auth.Username, auth.Password = value[:j], value[j+1:]
+			}
+
+			request.Auth = &store.Auth{Type: enums.AuthTypeBasic, Basic: auth}
+
+		case "-b", "--cookie":
+			if !strings.Contains(value, "=") {
+				r.warn("The cookie file %s was not imported", value)
+				continue
+			}
+
+			request.Headers = append(request.Headers, &store.Param{Key: "Cookie", Value: value, Enabled: true})
+
+		case "-A", "--user-agent":
+			request.Headers = append(request.Headers, &store.Param{Key: "User-Agent", Value: value, Enabled: true})
+
+		case "-e", "--referer":
+			request.Headers = append(request.Headers, &store.Param{Key: "Referer", Value: value, Enabled: true})
+
+		case "--url":
+			request.URL = value
+
+		case "-T", "--upload-file":
+			r.warn("The file %s of the body was not imported", value)
+			if method == "" {
+				method = enums.RequestTypePut
+			}
+
+		default:
+			if curlIgnoredOptions[arg] {
+				continue
+			}
+
+			if strings.HasPrefix(arg, "-") {
+				applyCurlFlag(r, arg, &method, &get)
+				continue
+			}
+
+			if request.URL == "" {
+				request.URL = arg
+			} else {
+				r.warn("The url %s was not imported, only one url is supported", arg)
+			}
+		}
+	}
+
+	if request.URL == "" {
+		return nil, nil, fmt.Errorf("invalid curl command: missing url")
+	}
+
+	// the body, the data is sent in the query string with -G
+	if len(data) > 0 && get {
+		separator := "?"
+		if strings.Contains(request.URL, "?") {
+			separator = "&"
+		}
+
+		request.URL += separator + strings.Join(data, "&")
+		data = nil
+	}
+
+	contentType := ""
+	for _, header := range request.Headers {
+		if strings.EqualFold(header.Key, "Content-Type") {
+			contentType = header.Value
+		}
+	}
+
+	switch {
+	case len(form) > 0:
+		request.BodyMode = enums.BodyModeFormData
+		request.MultipartParts = form
+
+		// the content type with the boundary is generated from the parts
+		headers := []*store.Param{}
+		for _, header := range request.Headers {
+			if !strings.EqualFold(header.Key, "Content-Type") {
+				headers = append(headers, header)
+			}
+		}
+		request.Headers = headers
+
+		if method == "" {
+			method = enums.RequestTypePost
+		}
+
+	case len(data) > 0:
+		body := strings.Join(data, "&")
+
+		if contentType == "" || strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+			request.BodyMode = enums.BodyModeURLEncoded
+			request.FormParams = parseURLEncoded(body)
+		} else {
+			request.BodyMode = enums.BodyModeRaw
+			request.Body = body
+			request.BodyContentType = contentType
+		}
+
+		if method == "" {
+			method = enums.RequestTypePost
+		}
+	}
+
+	// the request is named after its method and path
+	request.Name = request.URL
+
+	if method != "" {
+		setMethod(r, request, method)
+	}
+
+	if !strings.Contains(request.URL, "://") && !strings.HasPrefix(request.URL, "{{") {
+		request.URL = "http://" + request.URL
+	}
+
+	request.QueryParams = requestutils.ParseQueryParams(request.URL, nil)
+
+	// the basic auth can also be in the url or in a header
+	if u, err := url.Parse(request.URL); err == nil && u.User != nil && request.Auth == nil {
+		password, _ := u.User.Password()
+		request.Auth = &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{Username: u.User.Username(), Password: password}}
+		request.URL = strings.Replace(request.URL, u.User.String()+"@", "", 1)
+	}
+
+	if request.Auth == nil {
+		request.Auth = curlHeaderAuth(request)
+	}
+
+	if u, err := url.Parse(request.URL); err == nil && u.Path != "" {
+		request.Name = request.Type + " " + u.Path
+	} else {
+		request.Name = request.Type + " " + request.URL
+	}
+
+	return request, r.Warnings, nil
+}
+
+// applyCurlFlag applies an option of curl without value
+func applyCurlFlag(r *Result, flag string, method *string, get *bool) {
+	switch flag {
+	case "-G", "--get":
+		*get = true
+	case "-I", "--head":
+		*method = enums.RequestTypeHead
+	default:
+		r.warn("The option %s is not supported", flag)
+	}
+}
+
+// curlHeaderAuth moves a basic or bearer Authorization header to the auth of a request
+func curlHeaderAuth(request *store.Request) *store.Auth {
+	for i, header := range request.Headers {
+		if !strings.EqualFold(header.Key, "Authorization") {
+			continue
+		}
+
+		parts := strings.SplitN(header.Value, " ", 2)
+		if len(parts) != 2 {
+			return nil
+		}
+
+		var auth *store.Auth
+
+		switch strings.ToLower(parts[0]) {
+		case "bearer":
+			auth = &store.Auth{Type: enums.AuthTypeBearer, Bearer: &store.BearerAuth{Token: strings.TrimSpace(parts[1])}}
+
+		case "basic":
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return nil
+			}
+
+			credentials := strings.SplitN(string(decoded), ":", 2)
+			auth = &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{Username: credentials[0]}}
+			if len(credentials) > 1 {
+				auth.Basic.Password = credentials[1]
+			}
+
+		default:
+			return nil
+		}
+
+		request.Headers = append(request.Headers[:i:i], request.Headers[i+1:]...)
+
+		return auth
+	}
+
+	return nil
+}
+
+// curlURLEncode encodes the value of a --data-urlencode option, which can be content, =content or name=content
+func curlURLEncode(value string) string {
+	j := strings.Index(value, "=")
+	if j < 0 {
+		return url.QueryEscape(value)
+	}
+
+	if j == 0 {
+		return url.QueryEscape(value[1:])
+	}
+
+	return value[:j] + "=" + url.QueryEscape(value[j+1:])
+}
+
+// parseURLEncoded decodes the params of an x-www-form-urlencoded body
+func parseURLEncoded(body string) []*store.Param {
+	params := []*store.Param{}
+
+	for _, pair := range strings.Split(body, "&") {
+		if pair == "" {
+			continue
+		}
+
+		param := &store.Param{Enabled: true}
+		kv := strings.SplitN(pair, "=", 2)
+
+		param.Key = kv[0]
+		if key, err := url.QueryUnescape(kv[0]); err == nil {
+			param.Key = key
+		}
+
+		if len(kv) > 1 {
+			param.Value = kv[1]
+			if value, err := url.QueryUnescape(kv[1]); err == nil {
+				param.Value = value
+			}
+		}
+
+		params = append(params, param)
+	}
+
+	return params
+}
+
+// splitCommandLine splits a command line into arguments like a POSIX shell, with single and double
+// quotes, $'...' strings, escaped characters and lines continued with a backslash
+func splitCommandLine(command string) ([]string, error) {
+	args := []string{}
+	current := &strings.Builder{}
+	inArg := false
+
+	runes := []rune(command)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case c == '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated escape")
+			}
+
+			i++
+			if runes[i] == '\n' || (runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n') {
+				if runes[i] == '\r' {
+					i++
+				}
+				continue
+			}
+
+			current.WriteRune(runes[i])
+			inArg = true
+
+		case c == '\'':
+			end := indexRune(runes, '\'', i+1)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+			inArg = true
+
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			i += 2
+			for ; i < len(runes) && runes[i] != '\''; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						current.WriteRune('\n')
+					case 't':
+						current.WriteRune('\t')
+					case 'r':
+						current.WriteRune('\r')
+					default:
+						current.WriteRune(runes[i])
+					}
+					continue
+				}
+
+				current.WriteRune(runes[i])
+			}
+
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+
+			inArg = true
+
+		case c == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				// inside double quotes the backslash only escapes some characters
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+
+				current.WriteRune(runes[i])
+			}
+
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+
+			inArg = true
+
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// indexRune returns the index of the first rune c from a position, or -1 if it is not found
+func indexRune(runes []rune, c rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// stringSet returns a set with the given strings
+func stringSet(values ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
@@ -0,0 +1,272 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// harLog is a HAR 1.2 file, only the requests of the entries are imported
+type harLog struct {
+	Log struct {
+		Pages []struct {
+			Title string `json:"title"`
+		} `json:"pages"`
+		Entries []*harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+// harEntry is a request of a HAR file with its response
+type harEntry struct {
+	Comment string      `json:"comment"`
+	Request *harRequest `json:"request"`
+}
+
+// harRequest is a request of a HAR file
+type harRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	Headers     []*harPair   `json:"headers"`
+	Cookies     []*harPair   `json:"cookies"`
+	QueryString []*harPair   `json:"queryString"`
+	PostData    *harPostData `json:"postData"`
+}
+
+// harPair is a header, cookie, query param or form param of a HAR file
+type harPair struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Comment     string `json:"comment"`
+}
+
+// harPostData is the body of a request of a HAR file
+type harPostData struct {
+	MimeType string     `json:"mimeType"`
+	Params   []*harPair `json:"params"`
+	Text     string     `json:"text"`
+}
+
+// ImportHAR converts the requests of a HAR 1.2 file into a project, with a folder for each host.
+// The responses of the entries are not imported.
+func (i *Importer) ImportHAR(data []byte) (*Result, error) {
+	har := &harLog{}
+	if err := json.Unmarshal(data, har); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %v", err)
+	}
+
+	name := ""
+	if len(har.Log.Pages) > 0 {
+		name = har.Log.Pages[0].Title
+	}
+
+	r := i.newResult(name)
+	folders := map[string]*store.Folder{}
+
+	for n, entry := range har.Log.Entries {
+		if entry == nil || entry.Request == nil || entry.Request.URL == "" {
+			r.warn("Entry %d: the entry does not have a request, it was not imported", n+1)
+			continue
+		}
+
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil || u.Host == "" {
+			r.warn("Entry %d: the url %s is not valid, it was not imported", n+1, entry.Request.URL)
+			continue
+		}
+
+		folder := folders[u.Host]
+		if folder == nil {
+			folder, _ = i.addFolder(r, nil, 1, u.Host)
+			folders[u.Host] = folder
+		}
+
+		i.importHARRequest(r, entry, u, folder)
+	}
+
+	r.rank()
+
+	return r, nil
+}
+
+// importHARRequest adds the request of an entry to a folder
+func (i *Importer) importHARRequest(r *Result, entry *harEntry, u *url.URL, folder *store.Folder) {
+	name := entry.Comment
+	if name == "" {
+		name = strings.ToUpper(entry.Request.Method) + " " + u.EscapedPath()
+	}
+
+	request := i.newRequest(r, folder, name)
+	where := "Request " + quote(request.Name)
+
+	setMethod(r, request, entry.Request.Method)
+
+	// the query params of the url are kept as they were sent, so the query string of the entry
+	// is only used to add the params that are missing from the url
+	request.URL = entry.Request.URL
+	request.QueryParams = requestutils.ParseQueryParams(request.URL, nil)
+
+	present := map[string]bool{}
+	for _, param := range request.QueryParams {
+		if key, err := url.QueryUnescape(param.Key); err == nil {
+			present[key] = true
+		}
+	}
+
+	missing := false
+	for _, param := range entry.Request.QueryString {
+		if param == nil {
+			r.warn("%s: an empty query param was not imported", where)
+			continue
+		}
+
+		if !present[param.Name] {
+			request.QueryParams = append(request.QueryParams, &store.Param{
+				Key:     url.QueryEscape(param.Name),
+				Value:   url.QueryEscape(param.Value),
+				Enabled: true,
+			})
+			missing = true
+		}
+	}
+
+	if missing {
+		request.URL = requestutils.SetQueryParams(request.URL, request.QueryParams)
+	}
+
+	// headers, the pseudo headers of HTTP/2 and the headers that depend on the body are not imported
+	request.Headers = []*store.Param{}
+	hasCookie := false
+
+	for _, header := range entry.Request.Headers {
+		if header == nil {
+			r.warn("%s: an empty header was not imported", where)
+			continue
+		}
+
+		key := strings.ToLower(header.Name)
+
+		if strings.HasPrefix(key, ":") || key == "content-length" {
+			continue
+		}
+
+		if key == "content-type" && entry.Request.PostData != nil && strings.HasPrefix(entry.Request.PostData.MimeType, "multipart/form-data") {
+			continue
+		}
+
+		if key == "cookie" {
+			hasCookie = true
+		}
+
+		request.Headers = append(request.Headers, &store.Param{
+			Key:         header.Name,
+			Value:       header.Value,
+			Enabled:     true,
+			Description: header.Comment,
+		})
+	}
+
+	// the cookies are sent in the Cookie header, it is added if the entry only has the list of cookies
+	if !hasCookie && len(entry.Request.Cookies) > 0 {
+		cookies := []string{}
+		for _, cookie := range entry.Request.Cookies {
+			if cookie == nil {
+				r.warn("%s: an empty cookie was not imported", where)
+				continue
+			}
+
+			cookies = append(cookies, cookie.Name+"="+cookie.Value)
+		}
+
+		if len(cookies) > 0 {
+			request.Headers = append(request.Headers, &store.Param{Key: "Cookie", Value: strings.Join(cookies, "; "), Enabled: true})
+		}
+	}
+
+	importHARPostData(r, where, request, entry.Request.PostData)
+
+	r.Requests = append(r.Requests, request)
+}
+
+// importHARPostData sets the body of a request
+func importHARPostData(r *Result, where string, request *store.Request, postData *harPostData) {
+	if postData == nil || (postData.Text == "" && len(postData.Params) == 0) {
+		return
+	}
+
+	mimeType := strings.ToLower(strings.TrimSpace(strings.Split(postData.MimeType, ";")[0]))
+
+	switch mimeType {
+	case "application/x-www-form-urlencoded":
+		request.BodyMode = enums.BodyModeURLEncoded
+		request.FormParams = []*store.Param{}
+
+		params := postData.Params
+
+		// the params are decoded from the text when the entry does not have them
+		if len(params) == 0 {
+			for _, pair := range strings.Split(postData.Text, "&") {
+				if pair == "" {
+					continue
+				}
+
+				kv := strings.SplitN(pair, "=", 2)
+				param := &harPair{}
+				param.Name, _ = url.QueryUnescape(kv[0])
+				if len(kv) > 1 {
+					param.Value, _ = url.QueryUnescape(kv[1])
+				}
+
+				params = append(params, param)
+			}
+		}
+
+		for _, param := range params {
+			if param == nil {
+				r.warn("%s: an empty form param was not imported", where)
+				continue
+			}
+
+			request.FormParams = append(request.FormParams, &store.Param{Key: param.Name, Value: param.Value, Enabled: true})
+		}
+
+	case "multipart/form-data":
+		request.BodyMode = enums.BodyModeFormData
+		request.MultipartParts = []*store.MultipartPart{}
+
+		if len(postData.Params) == 0 {
+			r.warn("%s: the multipart body does not have params, it was not imported", where)
+		}
+
+		for _, param := range postData.Params {
+			if param == nil {
+				r.warn("%s: an empty form field was not imported", where)
+				continue
+			}
+
+			if param.FileName != "" {
+				r.warn("%s: the file of the form field %q was not imported", where, param.Name)
+				continue
+			}
+
+			request.MultipartParts = append(request.MultipartParts, &store.MultipartPart{
+				Key:         param.Name,
+				Type:        enums.MultipartPartTypeText,
+				Value:       param.Value,
+				ContentType: param.ContentType,
+				Enabled:     true,
+			})
+		}
+
+	default:
+		request.BodyMode = enums.BodyModeRaw
+		request.Body = postData.Text
+		request.BodyContentType = postData.MimeType
+	}
+}
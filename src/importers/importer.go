@@ -0,0 +1,191 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/rankutils"
+	"apiboy/backend/src/store"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IDGenerator generates the ids of the imported documents, it is implemented by store.Store
+type IDGenerator interface {
+	NewProjectID() string
+	NewFolderID() string
+	NewRequestID() string
+	NewEnvironmentID() string
+}
+
+// Importer converts the collections exported by other tools into projects
+type Importer struct {
+	IDs            IDGenerator
+	MaxFolderDepth int
+}
+
+// New returns a new Importer
+func New(ids IDGenerator, maxFolderDepth int) *Importer {
+	return &Importer{
+		IDs:            ids,
+		MaxFolderDepth: maxFolderDepth,
+	}
+}
+
+// Result contains the documents of an imported project, and the warnings
+// about the parts of the collection that could not be converted
+type Result struct {
+	Project      *store.Project
+	Folders      []*store.Folder
+	Requests     []*store.Request
+	Environments []*store.Environment
+	Warnings     []string
+}
+
+// newResult returns a new Result with an empty project
+func (i *Importer) newResult(name string) *Result {
+	if strings.TrimSpace(name) == "" {
+		name = "Imported Project"
+	}
+
+	return &Result{
+		Project: &store.Project{
+			ID:   i.IDs.NewProjectID(),
+			Name: strings.TrimSpace(name),
+		},
+		Folders:      []*store.Folder{},
+		Requests:     []*store.Request{},
+		Environments: []*store.Environment{},
+		Warnings:     []string{},
+	}
+}
+
+// warn adds a warning to the result
+func (r *Result) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// addFolder adds a folder to the project, the folders deeper than the maximum
+// depth are not created and their content is added to their parent folder
+func (i *Importer) addFolder(r *Result, parent *store.Folder, depth int, name string) (*store.Folder, int) {
+	if parent != nil && depth > i.MaxFolderDepth {
+		r.warn("Folder %q: the maximum folder depth was exceeded, its content was added to the folder %q", name, parent.Name)
+		return parent, depth - 1
+	}
+
+	folder := &store.Folder{
+		ID:        i.IDs.NewFolderID(),
+		Name:      strings.TrimSpace(name),
+		ProjectID: r.Project.ID,
+	}
+
+	if folder.Name == "" {
+		folder.Name = "Folder"
+	}
+
+	if parent != nil {
+		folder.ParentFolderID = parent.ID
+	}
+
+	r.Folders = append(r.Folders, folder)
+
+	return folder, depth
+}
+
+// newRequest returns a new request of a folder of the project
+func (i *Importer) newRequest(r *Result, folder *store.Folder, name string) *store.Request {
+	request := &store.Request{
+		ID:        i.IDs.NewRequestID(),
+		Name:      strings.TrimSpace(name),
+		FolderID:  folder.ID,
+		ProjectID: r.Project.ID,
+		Type:      enums.RequestTypeGet,
+		BodyMode:  enums.BodyModeNone,
+	}
+
+	if request.Name == "" {
+		request.Name = "Request"
+	}
+
+	return request
+}
+
+// setMethod sets the type of a request, the methods that are not supported are imported as GET
+func setMethod(r *Result, request *store.Request, method string) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+
+	if method == "" {
+		return
+	}
+
+	if !enums.IsValidRequestType(method) {
+		r.warn("Request %q: the method %s is not supported, it was imported as GET", request.Name, method)
+		return
+	}
+
+	request.Type = method
+}
+
+// rank sets the ranks of the folders and requests in the order they were added
+func (r *Result) rank() {
+	ranks := rankutils.Sequence(len(r.Folders) + len(r.Requests))
+
+	for i, folder := range r.Folders {
+		folder.Rank = ranks[i]
+	}
+
+	for i, request := range r.Requests {
+		request.Rank = ranks[len(r.Folders)+i]
+	}
+}
+
+// contentTypes are the content types of the languages of the raw bodies
+var contentTypes = map[string]string{
+	"json":       "application/json",
+	"xml":        "application/xml",
+	"html":       "text/html",
+	"javascript": "application/javascript",
+	"text":       "text/plain",
+}
+
+// decodeDocument decodes a JSON or YAML document, the YAML documents are converted to JSON
+// so the same struct tags are used for both formats
+func decodeDocument(data []byte, v interface{}) error {
+	if json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(yamlToJSON(document))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// yamlToJSON converts the maps decoded from YAML, which have keys of any type, into maps with string keys
+func yamlToJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, item := range v {
+			m[fmt.Sprint(key)] = yamlToJSON(item)
+		}
+		return m
+
+	case []interface{}:
+		for i, item := range v {
+			v[i] = yamlToJSON(item)
+		}
+		return v
+	}
+
+	return value
+}
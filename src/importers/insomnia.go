@@ -0,0 +1,521 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// insomniaVariablePattern matches the references to variables of Insomnia like {{ _.name }}
+var insomniaVariablePattern = regexp.MustCompile(`\{\{\s*_\.([^{}\s]+)\s*\}\}`)
+
+// insomniaReferencePattern matches the references to variables and the template tags, which are not escaped in the query params
+var insomniaReferencePattern = regexp.MustCompile(`\{\{[^{}]*\}\}|\{%[^{}]*%\}`)
+
+// insomniaExport is an Insomnia v4 export, with the resources of one or more workspaces
+type insomniaExport struct {
+	Type      string              `json:"_type"`
+	Format    int                 `json:"__export_format"`
+	Resources []*insomniaResource `json:"resources"`
+}
+
+// insomniaResource is a resource of an Insomnia export, the fields depend on its type
+type insomniaResource struct {
+	ID             string                 `json:"_id"`
+	Type           string                 `json:"_type"`
+	ParentID       string                 `json:"parentId"`
+	Name           string                 `json:"name"`
+	MetaSortKey    float64                `json:"metaSortKey"`
+	URL            string                 `json:"url"`
+	Method         string                 `json:"method"`
+	Body           *insomniaBody          `json:"body"`
+	Parameters     []*insomniaPair        `json:"parameters"`
+	Headers        []*insomniaPair        `json:"headers"`
+	Authentication *insomniaAuth          `json:"authentication"`
+	Environment    map[string]interface{} `json:"environment"`
+	Data           map[string]interface{} `json:"data"`
+	Cookies        []interface{}          `json:"cookies"`
+	Contents       string                 `json:"contents"`
+}
+
+// insomniaPair is a header, query param or form param of Insomnia
+type insomniaPair struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Disabled    bool   `json:"disabled"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	FileName    string `json:"fileName"`
+}
+
+// insomniaBody is the body of an Insomnia request
+type insomniaBody struct {
+	MimeType string          `json:"mimeType"`
+	Text     string          `json:"text"`
+	Params   []*insomniaPair `json:"params"`
+	FileName string          `json:"fileName"`
+}
+
+// insomniaAuth is the auth of an Insomnia request or folder, the fields depend on its type
+type insomniaAuth struct {
+	Type            string `json:"type"`
+	Disabled        bool   `json:"disabled"`
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	Token           string `json:"token"`
+	Prefix          string `json:"prefix"`
+	Key             string `json:"key"`
+	Value           string `json:"value"`
+	AddTo           string `json:"addTo"`
+	GrantType       string `json:"grantType"`
+	AccessTokenURL  string `json:"accessTokenUrl"`
+	ClientID        string `json:"clientId"`
+	ClientSecret    string `json:"clientSecret"`
+	Scope           string `json:"scope"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken"`
+	Region          string `json:"region"`
+	Service         string `json:"service"`
+}
+
+// insomniaImport is the state of an Insomnia export while it is imported
+type insomniaImport struct {
+	*Importer
+	children map[string][]*insomniaResource
+	tags     map[string]bool
+	empty    bool
+}
+
+// ImportInsomnia converts an Insomnia v4 export into projects, with a project for each workspace.
+// The request groups are imported as folders, the base environment of a workspace as the variables
+// of the project and its sub environments as environments.
+func (i *Importer) ImportInsomnia(data []byte) ([]*Result, error) {
+	export := &insomniaExport{}
+	if err := decodeDocument(data, export); err != nil {
+		return nil, fmt.Errorf("invalid Insomnia export: %v", err)
+	}
+
+	if export.Type != "export" || export.Format != 4 {
+		return nil, fmt.Errorf("invalid Insomnia export: only the version 4 of the export format is supported")
+	}
+
+	imp := &insomniaImport{
+		Importer: i,
+		children: map[string][]*insomniaResource{},
+	}
+
+	workspaces := []*insomniaResource{}
+
+	for _, resource := range export.Resources {
+		if resource == nil {
+			imp.empty = true
+			continue
+		}
+
+		if resource.Type == "workspace" {
+			workspaces = append(workspaces, resource)
+			continue
+		}
+
+		imp.children[resource.ParentID] = append(imp.children[resource.ParentID], resource)
+	}
+
+	if len(workspaces) == 0 {
+		return nil, fmt.Errorf("invalid Insomnia export: it does not have workspaces")
+	}
+
+	// the resources are sorted like in Insomnia
+	for _, children := range imp.children {
+		sort.SliceStable(children, func(a, b int) bool {
+			return children[a].MetaSortKey < children[b].MetaSortKey
+		})
+	}
+
+	results := []*Result{}
+
+	for _, workspace := range workspaces {
+		results = append(results, imp.importWorkspace(workspace))
+	}
+
+	return results, nil
+}
+
+// importWorkspace converts a workspace into a project
+func (imp *insomniaImport) importWorkspace(workspace *insomniaResource) *Result {
+	r := imp.newResult(workspace.Name)
+	imp.tags = map[string]bool{}
+
+	// the empty resources don't have a workspace, so they are reported in all of the projects
+	if imp.empty {
+		r.warn("Export: the empty resources were not imported")
+	}
+
+	// the requests in the root of the workspace are added to a folder, because the requests must be in a folder
+	var rootFolder *store.Folder
+	baseEnvironment := false
+
+	for _, resource := range imp.children[workspace.ID] {
+		switch resource.Type {
+		case "request_group":
+			imp.importFolder(r, resource, nil, 1)
+
+		case "request":
+			if rootFolder == nil {
+				rootFolder, _ = imp.addFolder(r, nil, 1, r.Project.Name)
+			}
+
+			imp.importRequest(r, resource, rootFolder)
+
+		case "environment":
+			if baseEnvironment {
+				r.warn("Environment %q: the workspace has more than one base environment, it was not imported", resource.Name)
+				continue
+			}
+
+			baseEnvironment = true
+			r.Project.Variables = imp.variables(r, "Environment "+quote(resource.Name), resource.Data)
+
+			for _, child := range imp.children[resource.ID] {
+				imp.importEnvironment(r, child)
+			}
+
+		default:
+			imp.unsupported(r, resource)
+		}
+	}
+
+	r.rank()
+
+	return r
+}
+
+// importFolder adds a request group with its subfolders and requests
+func (imp *insomniaImport) importFolder(r *Result, group *insomniaResource, parent *store.Folder, depth int) {
+	folder, depth := imp.addFolder(r, parent, depth, group.Name)
+	where := "Folder " + quote(group.Name)
+
+	if folder != parent {
+		folder.Variables = imp.variables(r, where, group.Environment)
+		folder.Headers = imp.params(r, where, "header", group.Headers)
+		folder.Auth = imp.auth(r, where, group.Authentication)
+	} else if len(group.Environment) > 0 || len(group.Headers) > 0 || group.Authentication != nil {
+		r.warn("%s: the environment, headers and auth of the folder were not imported", where)
+	}
+
+	for _, child := range imp.children[group.ID] {
+		switch child.Type {
+		case "request_group":
+			imp.importFolder(r, child, folder, depth+1)
+		case "request":
+			imp.importRequest(r, child, folder)
+		default:
+			imp.unsupported(r, child)
+		}
+	}
+}
+
+// importRequest adds a request to a folder
+func (imp *insomniaImport) importRequest(r *Result, resource *insomniaResource, folder *store.Folder) {
+	request := imp.newRequest(r, folder, resource.Name)
+	where := "Request " + quote(request.Name)
+
+	setMethod(r, request, resource.Method)
+
+	// the query params of Insomnia are kept apart from the url, so they are added to the query string of the url
+	request.URL = imp.text(r, where, resource.URL)
+	request.QueryParams = requestutils.ParseQueryParams(request.URL, nil)
+
+	for _, param := range resource.Parameters {
+		if param == nil {
+			r.warn("%s: an empty query param was not imported", where)
+			continue
+		}
+
+		request.QueryParams = append(request.QueryParams, &store.Param{
+			Key:         insomniaQueryEscape(imp.text(r, where, param.Name)),
+			Value:       insomniaQueryEscape(imp.text(r, where, param.Value)),
+			Enabled:     !param.Disabled,
+			Description: param.Description,
+		})
+	}
+
+	if len(resource.Parameters) > 0 {
+		request.URL = requestutils.SetQueryParams(request.URL, request.QueryParams)
+	}
+
+	request.Headers = imp.params(r, where, "header", resource.Headers)
+	request.Auth = imp.auth(r, where, resource.Authentication)
+
+	imp.importBody(r, where, request, resource.Body)
+
+	r.Requests = append(r.Requests, request)
+}
+
+// importBody sets the body of a request
+func (imp *insomniaImport) importBody(r *Result, where string, request *store.Request, body *insomniaBody) {
+	if body == nil || (body.MimeType == "" && body.Text == "") {
+		return
+	}
+
+	switch body.MimeType {
+	case "application/x-www-form-urlencoded":
+		request.BodyMode = enums.BodyModeURLEncoded
+		request.FormParams = imp.params(r, where, "form param", body.Params)
+
+	case "multipart/form-data":
+		request.BodyMode = enums.BodyModeFormData
+		request.MultipartParts = []*store.MultipartPart{}
+
+		for _, param := range body.Params {
+			if param == nil {
+				r.warn("%s: an empty form field was not imported", where)
+				continue
+			}
+
+			if param.Type == "file" {
+				r.warn("%s: the file of the form field %q was not imported", where, param.Name)
+				continue
+			}
+
+			request.MultipartParts = append(request.MultipartParts, &store.MultipartPart{
+				Key:         imp.text(r, where, param.Name),
+				Type:        enums.MultipartPartTypeText,
+				Value:       imp.text(r, where, param.Value),
+				Enabled:     !param.Disabled,
+				Description: param.Description,
+			})
+		}
+
+		// the Content-Type header of Insomnia does not have the boundary of the body
+		headers := []*store.Param{}
+		for _, header := range request.Headers {
+			if !strings.EqualFold(header.Key, "Content-Type") {
+				headers = append(headers, header)
+			}
+		}
+		request.Headers = headers
+
+	case "application/graphql":
+		request.BodyMode = enums.BodyModeGraphQL
+		request.GraphQL = &store.GraphQLBody{}
+
+		graphQL := struct {
+			Query     string          `json:"query"`
+			Variables json.RawMessage `json:"variables"`
+		}{}
+
+		if err := json.Unmarshal([]byte(body.Text), &graphQL); err != nil {
+			r.warn("%s: the GraphQL body is not valid, it was not imported", where)
+			return
+		}
+
+		request.GraphQL.Query = imp.text(r, where, graphQL.Query)
+
+		if len(graphQL.Variables) > 0 && string(graphQL.Variables) != "null" {
+			var variables interface{}
+			json.Unmarshal(graphQL.Variables, &variables)
+
+			data, _ := json.MarshalIndent(variables, "", "  ")
+			request.GraphQL.Variables = imp.text(r, where, string(data))
+		}
+
+	case "application/octet-stream":
+		request.BodyMode = enums.BodyModeBinary
+		r.warn("%s: the file of the body was not imported", where)
+
+	default:
+		request.BodyMode = enums.BodyModeRaw
+		request.Body = imp.text(r, where, body.Text)
+		request.BodyContentType = body.MimeType
+	}
+}
+
+// importEnvironment adds a sub environment of the base environment
+func (imp *insomniaImport) importEnvironment(r *Result, resource *insomniaResource) {
+	if resource.Type != "environment" {
+		imp.unsupported(r, resource)
+		return
+	}
+
+	environment := &store.Environment{
+		ID:        imp.IDs.NewEnvironmentID(),
+		Name:      strings.TrimSpace(resource.Name),
+		ProjectID: r.Project.ID,
+	}
+
+	if environment.Name == "" {
+		environment.Name = "Environment"
+	}
+
+	environment.Variables = imp.variables(r, "Environment "+quote(environment.Name), resource.Data)
+
+	r.Environments = append(r.Environments, environment)
+}
+
+// unsupported adds a warning for a resource that can't be imported, the empty cookie jars and API specs are ignored
+func (imp *insomniaImport) unsupported(r *Result, resource *insomniaResource) {
+	switch resource.Type {
+	case "cookie_jar":
+		if len(resource.Cookies) > 0 {
+			r.warn("The cookies of the cookie jar %q were not imported", resource.Name)
+		}
+	case "api_spec":
+		if strings.TrimSpace(resource.Contents) != "" {
+			r.warn("The API spec %q was not imported", resource.Name)
+		}
+	case "grpc_request":
+		r.warn("Request %q: the gRPC requests are not supported, it was not imported", resource.Name)
+	case "websocket_request":
+		r.warn("Request %q: the WebSocket requests are not supported, it was not imported", resource.Name)
+	case "unit_test_suite":
+		r.warn("The test suite %q was not imported", resource.Name)
+	default:
+		r.warn("The %s %q was not imported", strings.Replace(resource.Type, "_", " ", -1), resource.Name)
+	}
+}
+
+// params converts the headers or form params, the empty ones are not imported
+func (imp *insomniaImport) params(r *Result, where, name string, pairs []*insomniaPair) []*store.Param {
+	params := []*store.Param{}
+
+	for _, pair := range pairs {
+		if pair == nil {
+			r.warn("%s: an empty %s was not imported", where, name)
+			continue
+		}
+
+		params = append(params, &store.Param{
+			Key:         imp.text(r, where, pair.Name),
+			Value:       imp.text(r, where, pair.Value),
+			Enabled:     !pair.Disabled,
+			Description: pair.Description,
+		})
+	}
+
+	return params
+}
+
+// variables converts the data of an environment, the nested objects are flattened
+// with the keys joined by dots, like they are referenced in Insomnia
+func (imp *insomniaImport) variables(r *Result, where string, data map[string]interface{}) map[string]string {
+	values := map[string]string{}
+
+	var flatten func(prefix string, data map[string]interface{})
+	flatten = func(prefix string, data map[string]interface{}) {
+		for key, value := range data {
+			if object, ok := value.(map[string]interface{}); ok {
+				flatten(prefix+key+".", object)
+				continue
+			}
+
+			values[prefix+key] = imp.text(r, where, postmanValue(value))
+		}
+	}
+
+	flatten("", data)
+
+	return values
+}
+
+// auth converts an auth, the requests and folders without auth inherit it
+func (imp *insomniaImport) auth(r *Result, where string, auth *insomniaAuth) *store.Auth {
+	if auth == nil || auth.Type == "" {
+		return nil
+	}
+
+	if auth.Disabled || auth.Type == "none" {
+		return &store.Auth{Type: enums.AuthTypeNone}
+	}
+
+	t := func(s string) string {
+		return imp.text(r, where, s)
+	}
+
+	switch auth.Type {
+	case "basic":
+		return &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{Username: t(auth.Username), Password: t(auth.Password)}}
+
+	case "bearer":
+		// the tokens with a custom prefix are sent as an API key in the Authorization header
+		if auth.Prefix != "" && auth.Prefix != "Bearer" {
+			return &store.Auth{Type: enums.AuthTypeAPIKey, APIKey: &store.APIKeyAuth{Key: "Authorization", Value: t(auth.Prefix) + " " + t(auth.Token), In: enums.APIKeyInHeader}}
+		}
+
+		return &store.Auth{Type: enums.AuthTypeBearer, Bearer: &store.BearerAuth{Token: t(auth.Token)}}
+
+	case "apikey":
+		in := enums.APIKeyInHeader
+		switch auth.AddTo {
+		case "queryParams":
+			in = enums.APIKeyInQuery
+		case "cookie":
+			r.warn("%s: the API keys sent in cookies are not supported, the auth was not imported", where)
+			return nil
+		}
+
+		return &store.Auth{Type: enums.AuthTypeAPIKey, APIKey: &store.APIKeyAuth{Key: t(auth.Key), Value: t(auth.Value), In: in}}
+
+	case "digest":
+		return &store.Auth{Type: enums.AuthTypeDigest, Digest: &store.DigestAuth{Username: t(auth.Username), Password: t(auth.Password)}}
+
+	case "iam":
+		return &store.Auth{Type: enums.AuthTypeAWSV4, AWSV4: &store.AWSV4Auth{
+			AccessKey:    t(auth.AccessKeyID),
+			SecretKey:    t(auth.SecretAccessKey),
+			SessionToken: t(auth.SessionToken),
+			Region:       t(auth.Region),
+			Service:      t(auth.Service),
+		}}
+
+	case "oauth2":
+		if auth.GrantType != enums.OAuth2GrantClientCredentials && auth.GrantType != enums.OAuth2GrantPassword {
+			r.warn("%s: the OAuth2 grant type %s is not supported, the auth was not imported", where, auth.GrantType)
+			return nil
+		}
+
+		return &store.Auth{Type: enums.AuthTypeOAuth2, OAuth2: &store.OAuth2Auth{
+			GrantType:    auth.GrantType,
+			TokenURL:     t(auth.AccessTokenURL),
+			ClientID:     t(auth.ClientID),
+			ClientSecret: t(auth.ClientSecret),
+			Username:     t(auth.Username),
+			Password:     t(auth.Password),
+			Scope:        t(auth.Scope),
+		}}
+	}
+
+	r.warn("%s: the auth type %s is not supported, the auth was not imported", where, auth.Type)
+	return nil
+}
+
+// text converts the references to variables of Insomnia, the template tags like {% uuid %}
+// can't be converted, so a warning is added the first time they are found in a request, folder or environment
+func (imp *insomniaImport) text(r *Result, where, s string) string {
+	if strings.Contains(s, "{%") && !imp.tags[where] {
+		imp.tags[where] = true
+		r.warn("%s: the template tags were not converted", where)
+	}
+
+	return insomniaVariablePattern.ReplaceAllString(s, "{{$1}}")
+}
+
+// insomniaQueryEscape escapes a query param, except the references to variables and the template tags
+func insomniaQueryEscape(s string) string {
+	escaped := ""
+	last := 0
+
+	for _, match := range insomniaReferencePattern.FindAllStringIndex(s, -1) {
+		escaped += url.QueryEscape(s[last:match[0]]) + s[match[0]:match[1]]
+		last = match[1]
+	}
+
+	return escaped + url.QueryEscape(s[last:])
+}
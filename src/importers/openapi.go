@@ -0,0 +1,931 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// maxExampleDepth is the maximum depth of the examples generated from the schemas
+const maxExampleDepth = 8
+
+// openapiMethods are the methods of the operations of a path, in the order they are imported
+var openapiMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openapiDocument is an OpenAPI 3.x or Swagger 2.0 document, only the parts that are imported are decoded
+type openapiDocument struct {
+	OpenAPI string `json:"openapi"`
+	Swagger string `json:"swagger"`
+	Info    struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Tags     []*openapiTag                         `json:"tags"`
+	Paths    map[string]map[string]json.RawMessage `json:"paths"`
+	Security []map[string][]string                 `json:"security"`
+
+	// OpenAPI 3.x
+	Servers    []*openapiServer `json:"servers"`
+	Components struct {
+		Schemas         map[string]*openapiSchema         `json:"schemas"`
+		Parameters      map[string]*openapiParameter      `json:"parameters"`
+		RequestBodies   map[string]*openapiRequestBody    `json:"requestBodies"`
+		SecuritySchemes map[string]*openapiSecurityScheme `json:"securitySchemes"`
+	} `json:"components"`
+
+	// Swagger 2.0
+	Host                string                            `json:"host"`
+	BasePath            string                            `json:"basePath"`
+	Schemes             []string                          `json:"schemes"`
+	Consumes            []string                          `json:"consumes"`
+	Definitions         map[string]*openapiSchema         `json:"definitions"`
+	Parameters          map[string]*openapiParameter      `json:"parameters"`
+	SecurityDefinitions map[string]*openapiSecurityScheme `json:"securityDefinitions"`
+}
+
+// openapiTag is a tag of the operations
+type openapiTag struct {
+	Name string `json:"name"`
+}
+
+// openapiServer is a server of an OpenAPI 3.x document, its url can contain variables like {name}
+type openapiServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Variables   map[string]*struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+// openapiOperation is an operation of a path
+type openapiOperation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Tags        []string               `json:"tags"`
+	Parameters  []*openapiParameter    `json:"parameters"`
+	RequestBody *openapiRequestBody    `json:"requestBody"`
+	Consumes    []string               `json:"consumes"`
+	Security    *[]map[string][]string `json:"security"`
+	Callbacks   json.RawMessage        `json:"callbacks"`
+}
+
+// openapiParameter is a parameter of a path or an operation. In Swagger 2.0 the non body
+// parameters have their type in the parameter instead of a schema.
+type openapiParameter struct {
+	Ref         string                     `json:"$ref"`
+	Name        string                     `json:"name"`
+	In          string                     `json:"in"`
+	Description string                     `json:"description"`
+	Required    bool                       `json:"required"`
+	Schema      *openapiSchema             `json:"schema"`
+	Example     interface{}                `json:"example"`
+	Examples    map[string]*openapiExample `json:"examples"`
+	Type        string                     `json:"type"`
+	Format      string                     `json:"format"`
+	Items       *openapiSchema             `json:"items"`
+	Default     interface{}                `json:"default"`
+	Enum        []interface{}              `json:"enum"`
+}
+
+// openapiRequestBody is the body of an OpenAPI 3.x operation, by content type
+type openapiRequestBody struct {
+	Ref     string                       `json:"$ref"`
+	Content map[string]*openapiMediaType `json:"content"`
+}
+
+// openapiMediaType is a content type of a body
+type openapiMediaType struct {
+	Schema   *openapiSchema             `json:"schema"`
+	Example  interface{}                `json:"example"`
+	Examples map[string]*openapiExample `json:"examples"`
+}
+
+// openapiExample is a named example
+type openapiExample struct {
+	Value interface{} `json:"value"`
+}
+
+// openapiSchema is a JSON schema, its type can be a list of types in OpenAPI 3.1
+type openapiSchema struct {
+	Ref        string                    `json:"$ref"`
+	Type       json.RawMessage           `json:"type"`
+	Format     string                    `json:"format"`
+	Properties map[string]*openapiSchema `json:"properties"`
+	Items      *openapiSchema            `json:"items"`
+	AllOf      []*openapiSchema          `json:"allOf"`
+	OneOf      []*openapiSchema          `json:"oneOf"`
+	AnyOf      []*openapiSchema          `json:"anyOf"`
+	Example    interface{}               `json:"example"`
+	Examples   []interface{}             `json:"examples"`
+	Default    interface{}               `json:"default"`
+	Enum       []interface{}             `json:"enum"`
+}
+
+// openapiSecurityScheme is a security scheme, with the settings of OpenAPI 3.x and Swagger 2.0
+type openapiSecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+	Name   string `json:"name"`
+	In     string `json:"in"`
+	Flows  struct {
+		ClientCredentials *openapiOAuthFlow `json:"clientCredentials"`
+		Password          *openapiOAuthFlow `json:"password"`
+	} `json:"flows"`
+	Flow     string            `json:"flow"`
+	TokenURL string            `json:"tokenUrl"`
+	Scopes   map[string]string `json:"scopes"`
+}
+
+// openapiOAuthFlow is an OAuth2 flow of an OpenAPI 3.x security scheme
+type openapiOAuthFlow struct {
+	TokenURL string            `json:"tokenUrl"`
+	Scopes   map[string]string `json:"scopes"`
+}
+
+// openapiImport contains the state of an import
+type openapiImport struct {
+	*Importer
+	r       *Result
+	doc     *openapiDocument
+	swagger bool
+	folders map[string]*store.Folder
+}
+
+// ImportOpenAPI converts an OpenAPI 3.x or Swagger 2.0 document, in JSON or YAML, into a project.
+// Each tag is converted into a folder, each operation into a request and each server into an
+// environment with a baseUrl variable.
+func (i *Importer) ImportOpenAPI(data []byte) (*Result, error) {
+	doc := &openapiDocument{}
+	if err := decodeDocument(data, doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %v", err)
+	}
+
+	swagger := strings.HasPrefix(doc.Swagger, "2.")
+	if !swagger && !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("invalid OpenAPI document: only OpenAPI 3.x and Swagger 2.0 are supported")
+	}
+
+	imp := &openapiImport{
+		Importer: i,
+		r:        i.newResult(doc.Info.Title),
+		doc:      doc,
+		swagger:  swagger,
+		folders:  map[string]*store.Folder{},
+	}
+
+	imp.importServers()
+
+	// the folders of the declared tags are created in their order, even if they are empty
+	for _, tag := range doc.Tags {
+		imp.folder(tag.Name)
+	}
+
+	if len(doc.Security) > 0 {
+		imp.r.Project.Auth = imp.auth("Project", doc.Security)
+	}
+
+	paths := []string{}
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+
+		pathParameters := []*openapiParameter{}
+		if data, ok := item["parameters"]; ok {
+			json.Unmarshal(data, &pathParameters)
+		}
+
+		for _, method := range openapiMethods {
+			data, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			operation := &openapiOperation{}
+			if err := json.Unmarshal(data, operation); err != nil {
+				imp.r.warn("Operation %s %s: the operation is not valid, it was not imported", strings.ToUpper(method), path)
+				continue
+			}
+
+			imp.importOperation(path, method, pathParameters, operation)
+		}
+	}
+
+	imp.r.rank()
+
+	return imp.r, nil
+}
+
+// importServers converts the servers into environments with a baseUrl variable, the first
+// server is also the default value of the variable in the project
+func (imp *openapiImport) importServers() {
+	urls := []string{}
+	names := []string{}
+
+	if imp.swagger {
+		schemes := imp.doc.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{"https"}
+		}
+
+		if imp.doc.Host == "" {
+			urls = append(urls, imp.doc.BasePath)
+			names = append(names, "Default")
+		} else {
+			for _, scheme := range schemes {
+				urls = append(urls, scheme+"://"+imp.doc.Host+imp.doc.BasePath)
+				names = append(names, strings.ToUpper(scheme))
+			}
+		}
+	} else {
+		for _, server := range imp.doc.Servers {
+			serverURL := server.URL
+			for name, variable := range server.Variables {
+				if variable != nil {
+					serverURL = strings.Replace(serverURL, "{"+name+"}", variable.Default, -1)
+				}
+			}
+
+			name := server.Description
+			if name == "" {
+				name = serverURL
+			}
+
+			urls = append(urls, serverURL)
+			names = append(names, name)
+		}
+	}
+
+	imp.r.Project.Variables = map[string]string{}
+
+	for j, serverURL := range urls {
+		serverURL = strings.TrimSuffix(serverURL, "/")
+
+		if j == 0 {
+			imp.r.Project.Variables["baseUrl"] = serverURL
+		}
+
+		if serverURL == "" {
+			continue
+		}
+
+		imp.r.Environments = append(imp.r.Environments, &store.Environment{
+			ID:        imp.IDs.NewEnvironmentID(),
+			Name:      strings.TrimSpace(names[j]),
+			ProjectID: imp.r.Project.ID,
+			Variables: map[string]string{"baseUrl": serverURL},
+		})
+	}
+}
+
+// folder returns the folder of a tag, the operations without tags are added to a folder named after the project
+func (imp *openapiImport) folder(tag string) *store.Folder {
+	if tag == "" {
+		tag = imp.r.Project.Name
+	}
+
+	if folder, ok := imp.folders[tag]; ok {
+		return folder
+	}
+
+	folder, _ := imp.addFolder(imp.r, nil, 1, tag)
+	imp.folders[tag] = folder
+
+	return folder
+}
+
+// importOperation adds the request of an operation to the folder of its first tag
+func (imp *openapiImport) importOperation(path, method string, pathParameters []*openapiParameter, operation *openapiOperation) {
+	tag := ""
+	if len(operation.Tags) > 0 {
+		tag = operation.Tags[0]
+	}
+
+	name := operation.Summary
+	if name == "" {
+		name = operation.OperationID
+	}
+	if name == "" {
+		name = strings.ToUpper(method) + " " + path
+	}
+
+	request := imp.newRequest(imp.r, imp.folder(tag), name)
+	where := "Request " + quote(request.Name)
+
+	setMethod(imp.r, request, method)
+
+	request.QueryParams = []*store.Param{}
+	request.Headers = []*store.Param{}
+
+	if len(operation.Callbacks) > 0 {
+		imp.r.warn("%s: the callbacks were not imported", where)
+	}
+
+	// the parameters of the operation replace the parameters of the path with the same name and location
+	parameters := []*openapiParameter{}
+	index := map[string]int{}
+
+	for _, parameter := range append(append([]*openapiParameter{}, pathParameters...), operation.Parameters...) {
+		parameter = imp.parameter(parameter)
+		if parameter == nil {
+			continue
+		}
+
+		key := parameter.In + ":" + parameter.Name
+		if j, ok := index[key]; ok {
+			parameters[j] = parameter
+			continue
+		}
+
+		index[key] = len(parameters)
+		parameters = append(parameters, parameter)
+	}
+
+	// the path params with an example are replaced by its value, the others by a variable with their name
+	requestPath := path
+	formParameters := []*openapiParameter{}
+
+	for _, parameter := range parameters {
+		value := imp.parameterValue(parameter)
+
+		switch parameter.In {
+		case "path":
+			if value == "" {
+				value = "{{" + parameter.Name + "}}"
+			} else {
+				value = url.PathEscape(value)
+			}
+
+			requestPath = strings.Replace(requestPath, "{"+parameter.Name+"}", value, -1)
+
+		case "query":
+			request.QueryParams = append(request.QueryParams, &store.Param{
+				Key:         parameter.Name,
+				Value:       url.QueryEscape(value),
+				Enabled:     parameter.Required,
+				Description: parameter.Description,
+			})
+
+		case "header":
+			// the content type, accept and authorization headers are defined by the body and the auth
+			switch strings.ToLower(parameter.Name) {
+			case "content-type", "accept", "authorization":
+				continue
+			}
+
+			request.Headers = append(request.Headers, &store.Param{
+				Key:         parameter.Name,
+				Value:       value,
+				Enabled:     parameter.Required,
+				Description: parameter.Description,
+			})
+
+		case "body":
+			imp.setBody(request, where, "application/json", &openapiMediaType{Schema: parameter.Schema, Example: parameter.Example})
+
+		case "formData":
+			formParameters = append(formParameters, parameter)
+
+		default:
+			imp.r.warn("%s: the %s parameter %q was not imported", where, parameter.In, parameter.Name)
+		}
+	}
+
+	request.URL = requestutils.SetQueryParams("{{baseUrl}}"+requestPath, request.QueryParams)
+
+	if len(formParameters) > 0 {
+		imp.setFormBody(request, where, operation.Consumes, formParameters)
+	}
+
+	if requestBody := imp.requestBody(operation.RequestBody); requestBody != nil && len(requestBody.Content) > 0 {
+		contentType := preferredContentType(requestBody.Content)
+		imp.setBody(request, where, contentType, requestBody.Content[contentType])
+	}
+
+	// the operations without security requirements do not use the auth of the project
+	if operation.Security != nil {
+		if len(*operation.Security) == 0 {
+			request.Auth = &store.Auth{Type: enums.AuthTypeNone}
+		} else {
+			request.Auth = imp.auth(where, *operation.Security)
+		}
+	}
+
+	imp.r.Requests = append(imp.r.Requests, request)
+}
+
+// setBody sets the body of a request with the example of a content type
+func (imp *openapiImport) setBody(request *store.Request, where, contentType string, media *openapiMediaType) {
+	if media == nil {
+		media = &openapiMediaType{}
+	}
+
+	example := media.Example
+	if example == nil {
+		for _, name := range sortedExampleNames(media.Examples) {
+			example = media.Examples[name].Value
+			break
+		}
+	}
+	if example == nil && media.Schema != nil {
+		example = imp.example(media.Schema, 0, map[string]bool{})
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		params := []*store.Param{}
+		files := map[string]bool{}
+
+		if object, ok := example.(map[string]interface{}); ok {
+			schema := imp.resolveSchema(media.Schema, map[string]bool{})
+
+			for _, key := range sortedKeys(object) {
+				if schema != nil && schema.Properties[key] != nil && imp.resolveSchema(schema.Properties[key], map[string]bool{}).Format == "binary" {
+					files[key] = true
+				}
+
+				params = append(params, &store.Param{Key: key, Value: exampleString(object[key]), Enabled: true})
+			}
+		}
+
+		if mediaType == "multipart/form-data" {
+			imp.setMultipartParts(request, where, params, files)
+		} else {
+			request.BodyMode = enums.BodyModeURLEncoded
+			request.FormParams = params
+		}
+
+	case strings.HasSuffix(mediaType, "json"):
+		request.BodyMode = enums.BodyModeRaw
+		request.BodyContentType = contentType
+
+		if example != nil {
+			if s, ok := example.(string); ok && json.Valid([]byte(s)) {
+				request.Body = s
+			} else {
+				data, _ := json.MarshalIndent(example, "", "  ")
+				request.Body = string(data)
+			}
+		}
+
+	case mediaType == "application/octet-stream":
+		request.BodyMode = enums.BodyModeBinary
+		imp.r.warn("%s: the file of the body was not imported", where)
+
+	default:
+		request.BodyMode = enums.BodyModeRaw
+		request.BodyContentType = contentType
+
+		// only the text examples can be used in the other content types
+		if s, ok := example.(string); ok {
+			request.Body = s
+		} else if example != nil {
+			imp.r.warn("%s: the example of the %s body was not imported", where, mediaType)
+		}
+	}
+}
+
+// setFormBody sets the body of a request with the form parameters of a Swagger 2.0 operation
+func (imp *openapiImport) setFormBody(request *store.Request, where string, consumes []string, parameters []*openapiParameter) {
+	if len(consumes) == 0 {
+		consumes = imp.doc.Consumes
+	}
+
+	multipart := false
+	for _, contentType := range consumes {
+		if strings.HasPrefix(contentType, "multipart/form-data") {
+			multipart = true
+		}
+	}
+
+	params := []*store.Param{}
+	files := map[string]bool{}
+
+	for _, parameter := range parameters {
+		if parameter.Type == "file" {
+			multipart = true
+			files[parameter.Name] = true
+		}
+
+		params = append(params, &store.Param{
+			Key:         parameter.Name,
+			Value:       imp.parameterValue(parameter),
+			Enabled:     parameter.Required,
+			Description: parameter.Description,
+		})
+	}
+
+	if multipart {
+		imp.setMultipartParts(request, where, params, files)
+		return
+	}
+
+	request.BodyMode = enums.BodyModeURLEncoded
+	request.FormParams = params
+}
+
+// setMultipartParts sets a multipart body, the file fields are not imported
+func (imp *openapiImport) setMultipartParts(request *store.Request, where string, params []*store.Param, files map[string]bool) {
+	request.BodyMode = enums.BodyModeFormData
+	request.MultipartParts = []*store.MultipartPart{}
+
+	for _, param := range params {
+		if files[param.Key] {
+			imp.r.warn("%s: the file of the form field %q was not imported", where, param.Key)
+			continue
+		}
+
+		request.MultipartParts = append(request.MultipartParts, &store.MultipartPart{
+			Key:         param.Key,
+			Type:        enums.MultipartPartTypeText,
+			Value:       param.Value,
+			Enabled:     param.Enabled,
+			Description: param.Description,
+		})
+	}
+}
+
+// auth converts the first security requirement that can be converted
+func (imp *openapiImport) auth(where string, requirements []map[string][]string) *store.Auth {
+	schemes := imp.doc.Components.SecuritySchemes
+	if imp.swagger {
+		schemes = imp.doc.SecurityDefinitions
+	}
+
+	unsupported := []string{}
+
+	for _, requirement := range requirements {
+		// the requirements with many schemes need all of them, only one auth can be used
+		if len(requirement) != 1 {
+			for _, name := range sortedRequirementNames(requirement) {
+				unsupported = append(unsupported, name)
+			}
+			continue
+		}
+
+		for name, scopes := range requirement {
+			scheme := schemes[name]
+			if scheme == nil {
+				unsupported = append(unsupported, name)
+				continue
+			}
+
+			if auth := openapiAuth(scheme, scopes); auth != nil {
+				return auth
+			}
+
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		imp.r.warn("%s: the security schemes %s are not supported, the auth was not imported", where, strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
+
+// openapiAuth converts a security scheme, it returns nil if it is not supported
+func openapiAuth(scheme *openapiSecurityScheme, scopes []string) *store.Auth {
+	switch scheme.Type {
+	case "basic":
+		return &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{}}
+
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			return &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{}}
+		case "bearer":
+			return &store.Auth{Type: enums.AuthTypeBearer, Bearer: &store.BearerAuth{}}
+		case "digest":
+			return &store.Auth{Type: enums.AuthTypeDigest, Digest: &store.DigestAuth{}}
+		}
+
+	case "apiKey":
+		switch scheme.In {
+		case "header":
+			return &store.Auth{Type: enums.AuthTypeAPIKey, APIKey: &store.APIKeyAuth{Key: scheme.Name, In: enums.APIKeyInHeader}}
+		case "query":
+			return &store.Auth{Type: enums.AuthTypeAPIKey, APIKey: &store.APIKeyAuth{Key: scheme.Name, In: enums.APIKeyInQuery}}
+		}
+
+	case "oauth2":
+		auth := &store.OAuth2Auth{Scope: strings.Join(scopes, " ")}
+
+		switch {
+		case scheme.Flows.ClientCredentials != nil:
+			auth.GrantType = enums.OAuth2GrantClientCredentials
+			auth.TokenURL = scheme.Flows.ClientCredentials.TokenURL
+		case scheme.Flows.Password != nil:
+			auth.GrantType = enums.OAuth2GrantPassword
+			auth.TokenURL = scheme.Flows.Password.TokenURL
+		case scheme.Flow == "application":
+			auth.GrantType = enums.OAuth2GrantClientCredentials
+			auth.TokenURL = scheme.TokenURL
+		case scheme.Flow == "password":
+			auth.GrantType = enums.OAuth2GrantPassword
+			auth.TokenURL = scheme.TokenURL
+		default:
+			return nil
+		}
+
+		return &store.Auth{Type: enums.AuthTypeOAuth2, OAuth2: auth}
+	}
+
+	return nil
+}
+
+// parameter resolves the reference of a parameter, it returns nil if the reference is not found
+func (imp *openapiImport) parameter(parameter *openapiParameter) *openapiParameter {
+	if parameter == nil || parameter.Ref == "" {
+		return parameter
+	}
+
+	name := refName(parameter.Ref)
+
+	if imp.swagger {
+		return imp.doc.Parameters[name]
+	}
+
+	return imp.doc.Components.Parameters[name]
+}
+
+// requestBody resolves the reference of a body, it returns nil if the reference is not found
+func (imp *openapiImport) requestBody(body *openapiRequestBody) *openapiRequestBody {
+	if body == nil || body.Ref == "" {
+		return body
+	}
+
+	return imp.doc.Components.RequestBodies[refName(body.Ref)]
+}
+
+// resolveSchema resolves the references of a schema, it returns nil if a reference is
+// not found or it is circular
+func (imp *openapiImport) resolveSchema(schema *openapiSchema, seen map[string]bool) *openapiSchema {
+	for schema != nil && schema.Ref != "" {
+		if seen[schema.Ref] {
+			return nil
+		}
+
+		seen[schema.Ref] = true
+
+		if imp.swagger {
+			schema = imp.doc.Definitions[refName(schema.Ref)]
+		} else {
+			schema = imp.doc.Components.Schemas[refName(schema.Ref)]
+		}
+	}
+
+	return schema
+}
+
+// parameterValue returns the example value of a parameter as a string
+func (imp *openapiImport) parameterValue(parameter *openapiParameter) string {
+	if parameter.Example != nil {
+		return exampleString(parameter.Example)
+	}
+
+	for _, name := range sortedExampleNames(parameter.Examples) {
+		return exampleString(parameter.Examples[name].Value)
+	}
+
+	// the Swagger 2.0 parameters have the schema in the parameter
+	schema := parameter.Schema
+	if schema == nil && parameter.Type != "" {
+		schema = &openapiSchema{Format: parameter.Format, Items: parameter.Items, Default: parameter.Default, Enum: parameter.Enum}
+	}
+
+	if schema == nil {
+		return ""
+	}
+
+	schema = imp.resolveSchema(schema, map[string]bool{})
+	if schema == nil {
+		return ""
+	}
+
+	// only the explicit values are used, the values generated from the types are not useful as params
+	switch {
+	case schema.Example != nil:
+		return exampleString(schema.Example)
+	case len(schema.Examples) > 0:
+		return exampleString(schema.Examples[0])
+	case schema.Default != nil:
+		return exampleString(schema.Default)
+	case len(schema.Enum) > 0:
+		return exampleString(schema.Enum[0])
+	}
+
+	return ""
+}
+
+// example generates an example value of a schema, using the examples of the schema or values of its type
+func (imp *openapiImport) example(schema *openapiSchema, depth int, seen map[string]bool) interface{} {
+	if depth > maxExampleDepth {
+		return nil
+	}
+
+	// the references are resolved in a copy of the seen references, so they can be repeated in other branches
+	visited := map[string]bool{}
+	for ref := range seen {
+		visited[ref] = true
+	}
+
+	schema = imp.resolveSchema(schema, visited)
+	if schema == nil {
+		return nil
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		object := map[string]interface{}{}
+
+		for _, part := range schema.AllOf {
+			if value, ok := imp.example(part, depth+1, visited).(map[string]interface{}); ok {
+				for key, v := range value {
+					object[key] = v
+				}
+			}
+		}
+
+		return object
+	}
+
+	if len(schema.OneOf) > 0 {
+		return imp.example(schema.OneOf[0], depth+1, visited)
+	}
+
+	if len(schema.AnyOf) > 0 {
+		return imp.example(schema.AnyOf[0], depth+1, visited)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		object := map[string]interface{}{}
+
+		for key, property := range schema.Properties {
+			if value := imp.example(property, depth+1, visited); value != nil {
+				object[key] = value
+			}
+		}
+
+		return object
+
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+
+		if value := imp.example(schema.Items, depth+1, visited); value != nil {
+			return []interface{}{value}
+		}
+
+		return []interface{}{}
+
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "2020-01-01T00:00:00Z"
+		case "date":
+			return "2020-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		case "binary", "byte":
+			return ""
+		}
+
+		return "string"
+
+	case "integer", "number":
+		return 0
+
+	case "boolean":
+		return false
+	}
+
+	return nil
+}
+
+// schemaType returns the type of a schema, the first type that is not null if there are many types.
+// The schemas without type but with properties are objects.
+func schemaType(schema *openapiSchema) string {
+	t := ""
+	if err := json.Unmarshal(schema.Type, &t); err != nil {
+		types := []string{}
+		json.Unmarshal(schema.Type, &types)
+
+		for _, candidate := range types {
+			if candidate != "null" {
+				t = candidate
+				break
+			}
+		}
+	}
+
+	if t == "" && len(schema.Properties) > 0 {
+		return "object"
+	}
+
+	return t
+}
+
+// preferredContentType returns the JSON content type of a body, or the first content type in alphabetical order
+func preferredContentType(content map[string]*openapiMediaType) string {
+	contentTypes := []string{}
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	sort.Strings(contentTypes)
+
+	for _, contentType := range contentTypes {
+		if strings.HasPrefix(contentType, "application/json") {
+			return contentType
+		}
+	}
+
+	return contentTypes[0]
+}
+
+// exampleString returns an example value as a string, the objects and lists are converted to JSON
+func exampleString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// refName returns the name of the last part of a reference like #/components/schemas/Name
+func refName(ref string) string {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	name = strings.Replace(name, "~1", "/", -1)
+	return strings.Replace(name, "~0", "~", -1)
+}
+
+// sortedKeys returns the keys of an object sorted
+func sortedKeys(object map[string]interface{}) []string {
+	keys := []string{}
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedExampleNames returns the names of the examples sorted, the examples without value are excluded
+func sortedExampleNames(examples map[string]*openapiExample) []string {
+	names := []string{}
+	for name, example := range examples {
+		if example != nil && example.Value != nil {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// sortedRequirementNames returns the names of the schemes of a security requirement sorted
+func sortedRequirementNames(requirement map[string][]string) []string {
+	names := []string{}
+	for name := range requirement {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
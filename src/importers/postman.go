@@ -0,0 +1,557 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// postmanCollection is a Postman collection v2.1
+type postmanCollection struct {
+	Info struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []*postmanItem     `json:"item"`
+	Variable []*postmanVariable `json:"variable"`
+	Auth     *postmanAuth       `json:"auth"`
+	Event    []*postmanEvent    `json:"event"`
+}
+
+// postmanItem is a folder (with items) or a request of a Postman collection
+type postmanItem struct {
+	Name     string            `json:"name"`
+	Item     []*postmanItem    `json:"item"`
+	Request  *postmanRequest   `json:"request"`
+	Response []json.RawMessage `json:"response"`
+	Auth     *postmanAuth      `json:"auth"`
+	Event    []*postmanEvent   `json:"event"`
+}
+
+// postmanRequest is a request of a Postman collection, it can be just a url
+type postmanRequest struct {
+	Method string         `json:"method"`
+	URL    *postmanURL    `json:"url"`
+	Header []*postmanPair `json:"header"`
+	Body   *postmanBody   `json:"body"`
+	Auth   *postmanAuth   `json:"auth"`
+}
+
+// UnmarshalJSON decodes a request or a url
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	url := ""
+	if err := json.Unmarshal(data, &url); err == nil {
+		r.Method = enums.RequestTypeGet
+		r.URL = &postmanURL{Raw: url}
+		return nil
+	}
+
+	type request postmanRequest
+	return json.Unmarshal(data, (*request)(r))
+}
+
+// postmanURL is the url of a request of a Postman collection, it can be a string or an object
+type postmanURL struct {
+	Raw      string          `json:"raw"`
+	Protocol string          `json:"protocol"`
+	Host     json.RawMessage `json:"host"`
+	Path     json.RawMessage `json:"path"`
+	Query    []*postmanPair  `json:"query"`
+}
+
+// UnmarshalJSON decodes a string or an object
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	raw := ""
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+
+	type url postmanURL
+	return json.Unmarshal(data, (*url)(u))
+}
+
+// String returns the raw url, or builds it from its parts
+func (u *postmanURL) String() string {
+	if u.Raw != "" {
+		return u.Raw
+	}
+
+	url := ""
+	if u.Protocol != "" {
+		url = u.Protocol + "://"
+	}
+
+	url += joinPostmanParts(u.Host, ".")
+
+	if path := joinPostmanParts(u.Path, "/"); path != "" {
+		url += "/" + path
+	}
+
+	pairs := []string{}
+	for _, q := range u.Query {
+		if q != nil && !q.Disabled {
+			pairs = append(pairs, q.Key+"="+q.Value)
+		}
+	}
+
+	if len(pairs) > 0 {
+		url += "?" + strings.Join(pairs, "&")
+	}
+
+	return url
+}
+
+// joinPostmanParts joins the parts of a host or path, which can be a string or a list of strings
+func joinPostmanParts(data json.RawMessage, sep string) string {
+	s := ""
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s
+	}
+
+	parts := []string{}
+	json.Unmarshal(data, &parts)
+
+	return strings.Join(parts, sep)
+}
+
+// postmanPair is a header, query param or form param of a Postman collection
+type postmanPair struct {
+	Key         string          `json:"key"`
+	Value       string          `json:"value"`
+	Disabled    bool            `json:"disabled"`
+	Description json.RawMessage `json:"description"`
+	Type        string          `json:"type"`
+	Src         json.RawMessage `json:"src"`
+	ContentType string          `json:"contentType"`
+}
+
+// postmanBody is the body of a request of a Postman collection
+type postmanBody struct {
+	Mode       string         `json:"mode"`
+	Raw        string         `json:"raw"`
+	URLEncoded []*postmanPair `json:"urlencoded"`
+	FormData   []*postmanPair `json:"formdata"`
+	GraphQL    *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+	Options *struct {
+		Raw *struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+// postmanAuth is the auth of a Postman collection, folder or request, the settings
+// of each type are a list of key and value pairs
+type postmanAuth struct {
+	Type   string                `json:"type"`
+	Basic  []*postmanAuthSetting `json:"basic"`
+	Bearer []*postmanAuthSetting `json:"bearer"`
+	APIKey []*postmanAuthSetting `json:"apikey"`
+	Digest []*postmanAuthSetting `json:"digest"`
+	AWSV4  []*postmanAuthSetting `json:"awsv4"`
+	OAuth2 []*postmanAuthSetting `json:"oauth2"`
+}
+
+// postmanAuthSetting is a setting of an auth of a Postman collection
+type postmanAuthSetting struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// postmanVariable is a variable of a Postman collection or environment
+type postmanVariable struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Disabled bool        `json:"disabled"`
+	Enabled  *bool       `json:"enabled"`
+}
+
+// postmanEvent is a script of a Postman collection
+type postmanEvent struct {
+	Listen string `json:"listen"`
+}
+
+// postmanEnvironment is a Postman environment
+type postmanEnvironment struct {
+	Name   string             `json:"name"`
+	Values []*postmanVariable `json:"values"`
+}
+
+// ImportPostmanCollection converts a Postman collection v2.1 and its environments into a project
+func (i *Importer) ImportPostmanCollection(data []byte, environments [][]byte) (*Result, error) {
+	collection := &postmanCollection{}
+	if err := json.Unmarshal(data, collection); err != nil {
+		return nil, fmt.Errorf("invalid Postman collection: %v", err)
+	}
+
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, "v2.1") && !strings.Contains(collection.Info.Schema, "v2.0") {
+		return nil, fmt.Errorf("invalid Postman collection: unsupported schema %s", collection.Info.Schema)
+	}
+
+	r := i.newResult(collection.Info.Name)
+
+	r.Project.Variables = postmanVariables(r, "Collection", collection.Variable)
+	r.Project.Auth = postmanAuthSettings(r, "Collection", collection.Auth)
+	postmanEvents(r, "Collection", collection.Event)
+
+	// the requests in the root of the collection are added to a folder, because the requests must be in a folder
+	var rootFolder *store.Folder
+
+	for _, item := range collection.Item {
+		if item == nil {
+			r.warn("Collection: an empty item was not imported")
+			continue
+		}
+
+		if item.Request == nil && item.Item != nil {
+			i.importPostmanFolder(r, item, nil, 1)
+			continue
+		}
+
+		if rootFolder == nil {
+			rootFolder, _ = i.addFolder(r, nil, 1, r.Project.Name)
+		}
+
+		i.importPostmanRequest(r, item, rootFolder)
+	}
+
+	for _, data := range environments {
+		environment, err := i.importPostmanEnvironment(r, data)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Environments = append(r.Environments, environment)
+	}
+
+	r.rank()
+
+	return r, nil
+}
+
+// importPostmanFolder adds a folder with its subfolders and requests
+func (i *Importer) importPostmanFolder(r *Result, item *postmanItem, parent *store.Folder, depth int) {
+	folder, depth := i.addFolder(r, parent, depth, item.Name)
+
+	if folder != parent {
+		folder.Auth = postmanAuthSettings(r, "Folder "+quote(item.Name), item.Auth)
+	}
+
+	postmanEvents(r, "Folder "+quote(item.Name), item.Event)
+
+	for _, child := range item.Item {
+		if child == nil {
+			r.warn("Folder %s: an empty item was not imported", quote(item.Name))
+			continue
+		}
+
+		if child.Request == nil && child.Item != nil {
+			i.importPostmanFolder(r, child, folder, depth+1)
+		} else {
+			i.importPostmanRequest(r, child, folder)
+		}
+	}
+}
+
+// importPostmanRequest adds a request to a folder
+func (i *Importer) importPostmanRequest(r *Result, item *postmanItem, folder *store.Folder) {
+	request := i.newRequest(r, folder, item.Name)
+	where := "Request " + quote(request.Name)
+
+	postmanEvents(r, where, item.Event)
+
+	if len(item.Response) > 0 {
+		r.warn("%s: the saved responses were not imported", where)
+	}
+
+	if item.Request == nil {
+		r.warn("%s: the request is empty", where)
+		r.Requests = append(r.Requests, request)
+		return
+	}
+
+	setMethod(r, request, item.Request.Method)
+
+	// url and query params, the disabled query params are only in the list of params
+	if item.Request.URL != nil {
+		request.URL = item.Request.URL.String()
+		request.QueryParams = requestutils.ParseQueryParams(request.URL, postmanParams(r, where, "query param", item.Request.URL.Query))
+	} else {
+		request.QueryParams = []*store.Param{}
+	}
+
+	request.Headers = postmanParams(r, where, "header", item.Request.Header)
+	request.Auth = postmanAuthSettings(r, where, item.Request.Auth)
+
+	i.importPostmanBody(r, where, request, item.Request.Body)
+
+	r.Requests = append(r.Requests, request)
+}
+
+// importPostmanBody sets the body of a request
+func (i *Importer) importPostmanBody(r *Result, where string, request *store.Request, body *postmanBody) {
+	if body == nil || body.Mode == "" {
+		return
+	}
+
+	switch body.Mode {
+	case "raw":
+		request.BodyMode = enums.BodyModeRaw
+		request.Body = body.Raw
+
+		if body.Options != nil && body.Options.Raw != nil {
+			request.BodyContentType = contentTypes[body.Options.Raw.Language]
+		}
+
+	case "urlencoded":
+		request.BodyMode = enums.BodyModeURLEncoded
+		request.FormParams = postmanParams(r, where, "form param", body.URLEncoded)
+
+	case "formdata":
+		request.BodyMode = enums.BodyModeFormData
+		request.MultipartParts = []*store.MultipartPart{}
+
+		for _, pair := range body.FormData {
+			if pair == nil {
+				r.warn("%s: an empty form field was not imported", where)
+				continue
+			}
+
+			if pair.Type == "file" {
+				r.warn("%s: the file of the form field %q was not imported", where, pair.Key)
+				continue
+			}
+
+			request.MultipartParts = append(request.MultipartParts, &store.MultipartPart{
+				Key:         pair.Key,
+				Type:        enums.MultipartPartTypeText,
+				Value:       pair.Value,
+				ContentType: pair.ContentType,
+				Enabled:     !pair.Disabled,
+				Description: postmanDescription(pair.Description),
+			})
+		}
+
+	case "file":
+		request.BodyMode = enums.BodyModeBinary
+		r.warn("%s: the file of the body was not imported", where)
+
+	case "graphql":
+		request.BodyMode = enums.BodyModeGraphQL
+		request.GraphQL = &store.GraphQLBody{}
+
+		if body.GraphQL != nil {
+			request.GraphQL.Query = body.GraphQL.Query
+			request.GraphQL.Variables = body.GraphQL.Variables
+		}
+
+	default:
+		r.warn("%s: the body mode %s is not supported", where, body.Mode)
+	}
+}
+
+// importPostmanEnvironment converts a Postman environment
+func (i *Importer) importPostmanEnvironment(r *Result, data []byte) (*store.Environment, error) {
+	postmanEnvironment := &postmanEnvironment{}
+	if err := json.Unmarshal(data, postmanEnvironment); err != nil {
+		return nil, fmt.Errorf("invalid Postman environment: %v", err)
+	}
+
+	environment := &store.Environment{
+		ID:        i.IDs.NewEnvironmentID(),
+		Name:      strings.TrimSpace(postmanEnvironment.Name),
+		ProjectID: r.Project.ID,
+	}
+
+	if environment.Name == "" {
+		environment.Name = "Environment"
+	}
+
+	environment.Variables = postmanVariables(r, "Environment "+quote(environment.Name), postmanEnvironment.Values)
+
+	return environment, nil
+}
+
+// postmanParams converts the headers, query params or form params, the empty ones are not imported
+func postmanParams(r *Result, where, name string, pairs []*postmanPair) []*store.Param {
+	params := []*store.Param{}
+
+	for _, pair := range pairs {
+		if pair == nil {
+			r.warn("%s: an empty %s was not imported", where, name)
+			continue
+		}
+
+		params = append(params, &store.Param{
+			Key:         pair.Key,
+			Value:       pair.Value,
+			Enabled:     !pair.Disabled,
+			Description: postmanDescription(pair.Description),
+		})
+	}
+
+	return params
+}
+
+// postmanVariables converts the variables, the disabled variables are not imported
+func postmanVariables(r *Result, where string, variables []*postmanVariable) map[string]string {
+	values := map[string]string{}
+
+	for _, variable := range variables {
+		if variable == nil {
+			r.warn("%s: an empty variable was not imported", where)
+			continue
+		}
+
+		if variable.Disabled || (variable.Enabled != nil && !*variable.Enabled) {
+			r.warn("%s: the disabled variable %q was not imported", where, variable.Key)
+			continue
+		}
+
+		values[variable.Key] = postmanValue(variable.Value)
+	}
+
+	return values
+}
+
+// postmanAuthSettings converts an auth, the requests and folders without auth inherit it
+func postmanAuthSettings(r *Result, where string, auth *postmanAuth) *store.Auth {
+	if auth == nil {
+		return nil
+	}
+
+	settings := func(list []*postmanAuthSetting) map[string]string {
+		values := map[string]string{}
+		for _, setting := range list {
+			if setting == nil {
+				r.warn("%s: an empty auth setting was not imported", where)
+				continue
+			}
+
+			values[setting.Key] = postmanValue(setting.Value)
+		}
+		return values
+	}
+
+	switch auth.Type {
+	case "noauth":
+		return &store.Auth{Type: enums.AuthTypeNone}
+
+	case "inherit":
+		return &store.Auth{Type: enums.AuthTypeInherit}
+
+	case "basic":
+		s := settings(auth.Basic)
+		return &store.Auth{Type: enums.AuthTypeBasic, Basic: &store.BasicAuth{Username: s["username"], Password: s["password"]}}
+
+	case "bearer":
+		s := settings(auth.Bearer)
+		return &store.Auth{Type: enums.AuthTypeBearer, Bearer: &store.BearerAuth{Token: s["token"]}}
+
+	case "apikey":
+		s := settings(auth.APIKey)
+		in := enums.APIKeyInHeader
+		if s["in"] == "query" {
+			in = enums.APIKeyInQuery
+		}
+		return &store.Auth{Type: enums.AuthTypeAPIKey, APIKey: &store.APIKeyAuth{Key: s["key"], Value: s["value"], In: in}}
+
+	case "digest":
+		s := settings(auth.Digest)
+		return &store.Auth{Type: enums.AuthTypeDigest, Digest: &store.DigestAuth{Username: s["username"], Password: s["password"]}}
+
+	case "awsv4":
+		s := settings(auth.AWSV4)
+		return &store.Auth{Type: enums.AuthTypeAWSV4, AWSV4: &store.AWSV4Auth{
+			AccessKey:    s["accessKey"],
+			SecretKey:    s["secretKey"],
+			SessionToken: s["sessionToken"],
+			Region:       s["region"],
+			Service:      s["service"],
+		}}
+
+	case "oauth2":
+		s := settings(auth.OAuth2)
+
+		grantType := s["grant_type"]
+		if grantType == "password_credentials" {
+			grantType = enums.OAuth2GrantPassword
+		}
+
+		if grantType != enums.OAuth2GrantClientCredentials && grantType != enums.OAuth2GrantPassword {
+			r.warn("%s: the OAuth2 grant type %s is not supported, the auth was not imported", where, grantType)
+			return nil
+		}
+
+		return &store.Auth{Type: enums.AuthTypeOAuth2, OAuth2: &store.OAuth2Auth{
+			GrantType:    grantType,
+			TokenURL:     s["accessTokenUrl"],
+			ClientID:     s["clientId"],
+			ClientSecret: s["clientSecret"],
+			Username:     s["username"],
+			Password:     s["password"],
+			Scope:        s["scope"],
+		}}
+	}
+
+	r.warn("%s: the auth type %s is not supported, the auth was not imported", where, auth.Type)
+	return nil
+}
+
+// postmanEvents adds a warning for each script, the scripts can't be imported
+func postmanEvents(r *Result, where string, events []*postmanEvent) {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		switch event.Listen {
+		case "prerequest":
+			r.warn("%s: the pre-request script was not imported", where)
+		case "test":
+			r.warn("%s: the test script was not imported", where)
+		default:
+			r.warn("%s: the %s script was not imported", where, event.Listen)
+		}
+	}
+}
+
+// postmanDescription returns a description, which can be a string or an object with its content
+func postmanDescription(data json.RawMessage) string {
+	description := ""
+	if err := json.Unmarshal(data, &description); err == nil {
+		return description
+	}
+
+	object := struct {
+		Content string `json:"content"`
+	}{}
+	json.Unmarshal(data, &object)
+
+	return object.Content
+}
+
+// postmanValue returns a value as a string
+func postmanValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// quote returns a name between quotes for the warnings
+func quote(name string) string {
+	return fmt.Sprintf("%q", name)
+}
@@ -0,0 +1,81 @@
+package importers
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testIDs generates sequential ids for the tests
+type testIDs struct {
+	next int
+}
+
+func (g *testIDs) newID() string {
+	g.next++
+	return fmt.Sprintf("id%d", g.next)
+}
+
+func (g *testIDs) NewProjectID() string     { return g.newID() }
+func (g *testIDs) NewFolderID() string      { return g.newID() }
+func (g *testIDs) NewRequestID() string     { return g.newID() }
+func (g *testIDs) NewEnvironmentID() string { return g.newID() }
+
+func TestImportPostmanCollectionSkipsNullEntries(t *testing.T) {
+	collection := `{
+		"info": {"name": "Nulls", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [null],
+		"event": [null],
+		"item": [
+			null,
+			{"name": "Folder", "item": [
+				null,
+				{"name": "Request", "request": {
+					"method": "POST",
+					"url": {"raw": "https://example.com/?a=1", "query": [null, {"key": "a", "value": "1"}]},
+					"header": [null, {"key": "Accept", "value": "application/json"}],
+					"body": {"mode": "urlencoded", "urlencoded": [null, {"key": "name", "value": "value"}]},
+					"auth": {"type": "basic", "basic": [null, {"key": "username", "value": "user"}]}
+				}},
+				{"name": "Form", "request": {
+					"method": "POST",
+					"url": "https://example.com/form",
+					"body": {"mode": "formdata", "formdata": [null, {"key": "field", "value": "value", "type": "text"}]}
+				}}
+			]}
+		]
+	}`
+
+	result, err := New(&testIDs{}, 10).ImportPostmanCollection([]byte(collection), [][]byte{[]byte(`{"name": "Env", "values": [null]}`)})
+	if err != nil {
+		t.Fatalf("expected the collection to be imported, got %v", err)
+	}
+
+	if len(result.Requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(result.Requests))
+	}
+
+	request := result.Requests[0]
+	if len(request.Headers) != 1 || request.Headers[0].Key != "Accept" {
+		t.Errorf("expected the Accept header, got %v", request.Headers)
+	}
+
+	if len(request.QueryParams) != 1 || request.QueryParams[0].Key != "a" {
+		t.Errorf("expected the query param a, got %v", request.QueryParams)
+	}
+
+	if len(request.FormParams) != 1 || request.FormParams[0].Key != "name" {
+		t.Errorf("expected the form param name, got %v", request.FormParams)
+	}
+
+	if request.Auth == nil || request.Auth.Basic == nil || request.Auth.Basic.Username != "user" {
+		t.Errorf("expected the basic auth username, got %v", request.Auth)
+	}
+
+	if parts := result.Requests[1].MultipartParts; len(parts) != 1 || parts[0].Key != "field" {
+		t.Errorf("expected the form field, got %v", parts)
+	}
+
+	if len(result.Warnings) == 0 {
+		t.Errorf("expected warnings about the null entries")
+	}
+}
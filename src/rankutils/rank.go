@@ -0,0 +1,137 @@
+package rankutils
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// digits are the digits of the ranks, sorted by their byte value
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// random generates the jitter of the ranks, the mutex is needed because the source is not safe for concurrent use
+var random = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// Between returns a rank that is sorted between two ranks (fractional indexing).
+// An empty rank is the start of the list for a and the end of the list for b,
+// otherwise a must be less than b and the ranks can't end with the digit 0.
+func Between(a, b string) string {
+	// keep the common prefix
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+
+		if n > 0 {
+			return b[:n] + Between(tail(a, n), b[n:])
+		}
+	}
+
+	digitA := indexAt(a, 0, 0)
+	digitB := indexAt(b, 0, len(digits))
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+
+	// the digits are consecutive, so the first digit of b is enough if it has more digits
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	return string(digits[digitA]) + Between(tail(a, 1), "")
+}
+
+// Random returns a rank between two ranks like Between, with a random suffix so the ranks
+// generated at the same time for the same position are not equal
+func Random(a, b string) string {
+	rank := Between(a, b)
+
+	random.Lock()
+	jittered := rank + string(digits[1+random.Intn(len(digits)-1)])
+	random.Unlock()
+
+	if (a == "" || a < jittered) && (b == "" || jittered < b) {
+		return jittered
+	}
+
+	return rank
+}
+
+// Sequence returns n sorted ranks with the same length, evenly spaced so there is room
+// to add items between them. The last digit of the ranks is never 0.
+func Sequence(n int) []string {
+	// the ranks of a length have any digits except the last one, which can't be 0
+	width := 1
+	total := int64(len(digits) - 1)
+
+	for total < int64(n+1) {
+		width++
+		total *= int64(len(digits))
+	}
+
+	ranks := make([]string, n)
+
+	for i := range ranks {
+		position := int64(i+1) * total / int64(n+1)
+
+		rank := make([]byte, width)
+		rank[width-1] = digits[1+position%int64(len(digits)-1)]
+		position /= int64(len(digits) - 1)
+
+		for j := width - 2; j >= 0; j-- {
+			rank[j] = digits[position%int64(len(digits))]
+			position /= int64(len(digits))
+		}
+
+		ranks[i] = string(rank)
+	}
+
+	return ranks
+}
+
+// Less sorts the items by rank and then by name, the items created before
+// the ranks have an empty rank and are sorted first
+func Less(rankA, nameA, rankB, nameB string) bool {
+	if rankA != rankB {
+		return rankA < rankB
+	}
+
+	return strings.ToLower(nameA) < strings.ToLower(nameB)
+}
+
+// digitAt returns the digit of a rank in a position, the missing digits are 0
+func digitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+
+	return digits[0]
+}
+
+// indexAt returns the value of the digit of a rank in a position, or the default value if it is too short
+func indexAt(rank string, i int, defaultValue int) int {
+	if i < len(rank) {
+		for j := 0; j < len(digits); j++ {
+			if digits[j] == rank[i] {
+				return j
+			}
+		}
+	}
+
+	return defaultValue
+}
+
+// tail returns the digits of a rank after a position
+func tail(rank string, i int) string {
+	if i < len(rank) {
+		return rank[i:]
+	}
+
+	return ""
+}
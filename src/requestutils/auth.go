@@ -0,0 +1,117 @@
+package requestutils
+
+import (
+	"context"
+	"net/http"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+)
+
+// ResolveAuth returns the auth that applies to a request given the auths of the request and its
+// ancestors, from the nearest to the farthest. An empty or inherit auth uses the next one, and
+// the requests without any auth are not authenticated.
+func ResolveAuth(auths ...*store.Auth) *store.Auth {
+	for _, auth := range auths {
+		if auth != nil && auth.Type != "" && auth.Type != enums.AuthTypeInherit {
+			return auth
+		}
+	}
+
+	return &store.Auth{Type: enums.AuthTypeNone}
+}
+
+// AuthWithVariables returns a copy of an auth with the references to variables replaced
+func AuthWithVariables(auth *store.Auth, variables map[string]string) *store.Auth {
+	r := func(s string) string {
+		return ReplaceVariables(s, variables)
+	}
+
+	resolved := &store.Auth{Type: auth.Type}
+
+	if auth.Basic != nil {
+		resolved.Basic = &store.BasicAuth{Username: r(auth.Basic.Username), Password: r(auth.Basic.Password)}
+	}
+
+	if auth.Bearer != nil {
+		resolved.Bearer = &store.BearerAuth{Token: r(auth.Bearer.Token)}
+	}
+
+	if auth.APIKey != nil {
+		resolved.APIKey = &store.APIKeyAuth{Key: r(auth.APIKey.Key), Value: r(auth.APIKey.Value), In: auth.APIKey.In}
+	}
+
+	if auth.Digest != nil {
+		resolved.Digest = &store.DigestAuth{Username: r(auth.Digest.Username), Password: r(auth.Digest.Password)}
+	}
+
+	if auth.AWSV4 != nil {
+		resolved.AWSV4 = &store.AWSV4Auth{
+			AccessKey:    r(auth.AWSV4.AccessKey),
+			SecretKey:    r(auth.AWSV4.SecretKey),
+			SessionToken: r(auth.AWSV4.SessionToken),
+			Region:       r(auth.AWSV4.Region),
+			Service:      r(auth.AWSV4.Service),
+		}
+	}
+
+	if auth.OAuth2 != nil {
+		resolved.OAuth2 = &store.OAuth2Auth{
+			GrantType:    auth.OAuth2.GrantType,
+			TokenURL:     r(auth.OAuth2.TokenURL),
+			ClientID:     r(auth.OAuth2.ClientID),
+			ClientSecret: r(auth.OAuth2.ClientSecret),
+			Username:     r(auth.OAuth2.Username),
+			Password:     r(auth.OAuth2.Password),
+			Scope:        r(auth.OAuth2.Scope),
+		}
+	}
+
+	return resolved
+}
+
+// ApplyAuth adds the credentials of an auth to an http request, the body is the payload of the request.
+// The OAuth2 access tokens are requested with the given client and cached until they expire.
+// The Digest auth needs a challenge from the server, so it is applied with ApplyDigestAuth
+// after the server responds with a 401 status code.
+func ApplyAuth(ctx context.Context, req *http.Request, body []byte, auth *store.Auth, client *http.Client) error {
+	switch auth.Type {
+	case enums.AuthTypeBasic:
+		if auth.Basic != nil {
+			req.SetBasicAuth(auth.Basic.Username, auth.Basic.Password)
+		}
+
+	case enums.AuthTypeBearer:
+		if auth.Bearer != nil {
+			req.Header.Set("Authorization", "Bearer "+auth.Bearer.Token)
+		}
+
+	case enums.AuthTypeAPIKey:
+		if auth.APIKey != nil && auth.APIKey.Key != "" {
+			if auth.APIKey.In == enums.APIKeyInQuery {
+				query := req.URL.Query()
+				query.Set(auth.APIKey.Key, auth.APIKey.Value)
+				req.URL.RawQuery = query.Encode()
+			} else {
+				req.Header.Set(auth.APIKey.Key, auth.APIKey.Value)
+			}
+		}
+
+	case enums.AuthTypeAWSV4:
+		if auth.AWSV4 != nil {
+			SignAWSV4(req, body, auth.AWSV4, timeNow())
+		}
+
+	case enums.AuthTypeOAuth2:
+		if auth.OAuth2 != nil {
+			token, err := GetOAuth2Token(ctx, client, auth.OAuth2)
+			if err != nil {
+				return err
+			}
+
+			req.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package requestutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+)
+
+// BlobGetter returns a blob by id, or nil if it does not exist
+type BlobGetter func(id string) (*store.Blob, error)
+
+// BuildBody returns the payload and the content type of the body of a request according to its mode.
+// The content type is empty when the mode does not define one, and a Content-Type header of the
+// request should take precedence over the returned content type.
+func BuildBody(request *store.Request, getBlob BlobGetter) ([]byte, string, error) {
+	switch request.BodyMode {
+	case enums.BodyModeNone:
+		return nil, "", nil
+
+	case enums.BodyModeURLEncoded:
+		pairs := []string{}
+
+		for _, param := range request.FormParams {
+			if param != nil && param.Enabled && param.Key != "" {
+				pairs = append(pairs, url.QueryEscape(param.Key)+"="+url.QueryEscape(param.Value))
+			}
+		}
+
+		return []byte(strings.Join(pairs, "&")), "application/x-www-form-urlencoded", nil
+
+	case enums.BodyModeFormData:
+		return buildMultipartBody(request.MultipartParts, getBlob)
+
+	case enums.BodyModeBinary:
+		blob, err := getRequiredBlob(request.BinaryBlobID, getBlob)
+		if err != nil {
+			return nil, "", err
+		}
+
+		contentType := blob.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		return blob.Data, contentType, nil
+
+	case enums.BodyModeGraphQL:
+		payload := map[string]interface{}{}
+
+		if request.GraphQL != nil {
+			payload["query"] = request.GraphQL.Query
+
+			if strings.TrimSpace(request.GraphQL.Variables) != "" {
+				var variables interface{}
+
+				if err := json.Unmarshal([]byte(request.GraphQL.Variables), &variables); err != nil {
+					return nil, "", fmt.Errorf("Invalid GraphQL variables: %v", err)
+				}
+
+				payload["variables"] = variables
+			}
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return data, "application/json", nil
+
+	default:
+		return []byte(request.Body), request.BodyContentType, nil
+	}
+}
+
+// buildMultipartBody returns a multipart/form-data body with the enabled parts
+func buildMultipartBody(parts []*store.MultipartPart, getBlob BlobGetter) ([]byte, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for _, part := range parts {
+		if part == nil || !part.Enabled || part.Key == "" {
+			continue
+		}
+
+		if part.Type != enums.MultipartPartTypeFile {
+			if err := writer.WriteField(part.Key, part.Value); err != nil {
+				return nil, "", err
+			}
+
+			continue
+		}
+
+		blob, err := getRequiredBlob(part.BlobID, getBlob)
+		if err != nil {
+			return nil, "", err
+		}
+
+		contentType := part.ContentType
+		if contentType == "" {
+			contentType = blob.ContentType
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(part.Key), escapeQuotes(blob.FileName)))
+		header.Set("Content-Type", contentType)
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err := w.Write(blob.Data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// getRequiredBlob returns a blob, or an error if it does not exist
+func getRequiredBlob(id string, getBlob BlobGetter) (*store.Blob, error) {
+	if id == "" {
+		return nil, fmt.Errorf("Missing file")
+	}
+
+	blob, err := getBlob(id)
+	if err != nil {
+		return nil, err
+	} else if blob == nil {
+		return nil, fmt.Errorf("File not found: %s", id)
+	}
+
+	return blob, nil
+}
+
+// escapeQuotes escapes the characters that are not allowed inside a quoted header value
+func escapeQuotes(s string) string {
+	return strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace(s)
+}
+
+// WithBodyVariables returns a copy of a request with the references to variables of its body replaced,
+// the files of the body are kept as they are
+func WithBodyVariables(request *store.Request, variables map[string]string) *store.Request {
+	r := func(s string) string {
+		return ReplaceVariables(s, variables)
+	}
+
+	copied := *request
+	copied.Body = r(request.Body)
+	copied.FormParams = []*store.Param{}
+	copied.MultipartParts = []*store.MultipartPart{}
+
+	for _, param := range request.FormParams {
+		if param == nil {
+			continue
+		}
+
+		p := *param
+		p.Key, p.Value = r(param.Key), r(param.Value)
+		copied.FormParams = append(copied.FormParams, &p)
+	}
+
+	for _, part := range request.MultipartParts {
+		if part == nil {
+			continue
+		}
+
+		p := *part
+		p.Key = r(part.Key)
+		if part.Type != enums.MultipartPartTypeFile {
+			p.Value = r(part.Value)
+		}
+		copied.MultipartParts = append(copied.MultipartParts, &p)
+	}
+
+	if request.GraphQL != nil {
+		copied.GraphQL = &store.GraphQLBody{Query: r(request.GraphQL.Query), Variables: r(request.GraphQL.Variables)}
+	}
+
+	return &copied
+}
@@ -0,0 +1,133 @@
+package requestutils
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	"apiboy/backend/src/store"
+)
+
+// ApplyDigestAuth adds the Authorization header of the Digest auth to an http request,
+// answering the challenge of the WWW-Authenticate header of a 401 response
+func ApplyDigestAuth(req *http.Request, challenge string, auth *store.DigestAuth) error {
+	if !strings.HasPrefix(strings.ToLower(challenge), "digest ") {
+		return fmt.Errorf("unsupported authentication challenge: %s", challenge)
+	}
+
+	params := parseChallenge(challenge[len("digest "):])
+
+	algorithm := params["algorithm"]
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	var newHash func() hash.Hash
+
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return fmt.Errorf("unsupported digest algorithm: %s", algorithm)
+	}
+
+	h := func(s string) string {
+		hh := newHash()
+		hh.Write([]byte(s))
+		return hex.EncodeToString(hh.Sum(nil))
+	}
+
+	cnonceBytes := make([]byte, 8)
+	rand.Read(cnonceBytes)
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nc := "00000001"
+
+	realm := params["realm"]
+	nonce := params["nonce"]
+	uri := req.URL.RequestURI()
+
+	ha1 := h(auth.Username + ":" + realm + ":" + auth.Password)
+	if strings.HasSuffix(strings.ToUpper(algorithm), "-SESS") {
+		ha1 = h(ha1 + ":" + nonce + ":" + cnonce)
+	}
+
+	ha2 := h(req.Method + ":" + uri)
+
+	// only the auth quality of protection is supported
+	qop := ""
+	for _, value := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(value) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	var response string
+	if qop != "" {
+		response = h(strings.Join([]string{ha1, nonce, nc, cnonce, qop, ha2}, ":"))
+	} else {
+		response = h(ha1 + ":" + nonce + ":" + ha2)
+	}
+
+	header := fmt.Sprintf(
+		`Digest username="%s", realm="%s", nonce="%s", uri="%s", algorithm=%s, response="%s"`,
+		auth.Username, realm, nonce, uri, algorithm, response,
+	)
+
+	if qop != "" {
+		header += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+
+	if opaque, ok := params["opaque"]; ok {
+		header += fmt.Sprintf(`, opaque="%s"`, opaque)
+	}
+
+	req.Header.Set("Authorization", header)
+
+	return nil
+}
+
+// parseChallenge parses the comma separated params of an authentication challenge
+func parseChallenge(s string) map[string]string {
+	params := map[string]string{}
+
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " ,")
+
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " ")
+
+		var value string
+
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.Index(s, ",")
+			if end < 0 {
+				value, s = s, ""
+			} else {
+				value, s = s[:end], s[end:]
+			}
+		}
+
+		params[key] = strings.TrimSpace(value)
+	}
+
+	return params
+}
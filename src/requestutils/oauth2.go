@@ -0,0 +1,166 @@
+package requestutils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+)
+
+const (
+	// oauth2ExpiryMargin is the time before the expiration when a cached token is requested again
+	oauth2ExpiryMargin = 30 * time.Second
+
+	// oauth2MaxTokens is the maximum number of cached access tokens
+	oauth2MaxTokens = 1000
+)
+
+// OAuth2Token is an access token returned by an OAuth2 token endpoint
+type OAuth2Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int64     `json:"expires_in"`
+	ExpiresAt   time.Time `json:"-"`
+}
+
+// oauth2Tokens caches the access tokens by the settings used to request them
+var oauth2Tokens = struct {
+	sync.Mutex
+	tokens map[string]*OAuth2Token
+}{tokens: map[string]*OAuth2Token{}}
+
+// GetOAuth2Token returns a cached access token for the settings or requests a new one
+func GetOAuth2Token(ctx context.Context, client *http.Client, auth *store.OAuth2Auth) (*OAuth2Token, error) {
+	key := oauth2CacheKey(auth)
+
+	oauth2Tokens.Lock()
+	token, ok := oauth2Tokens.tokens[key]
+	if ok && !time.Now().Before(token.ExpiresAt) {
+		delete(oauth2Tokens.tokens, key)
+		ok = false
+	}
+	oauth2Tokens.Unlock()
+
+	if ok {
+		return token, nil
+	}
+
+	token, err := requestOAuth2Token(ctx, client, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth2Tokens.Lock()
+	cacheOAuth2Token(key, token)
+	oauth2Tokens.Unlock()
+
+	return token, nil
+}
+
+// cacheOAuth2Token adds a token to the cache, the expired tokens are removed first and,
+// if the cache is still full, the token that expires sooner is removed. The cache must be locked.
+func cacheOAuth2Token(key string, token *OAuth2Token) {
+	if _, ok := oauth2Tokens.tokens[key]; !ok && len(oauth2Tokens.tokens) >= oauth2MaxTokens {
+		now := time.Now()
+		for k, t := range oauth2Tokens.tokens {
+			if !now.Before(t.ExpiresAt) {
+				delete(oauth2Tokens.tokens, k)
+			}
+		}
+
+		if len(oauth2Tokens.tokens) >= oauth2MaxTokens {
+			oldest := ""
+			for k, t := range oauth2Tokens.tokens {
+				if oldest == "" || t.ExpiresAt.Before(oauth2Tokens.tokens[oldest].ExpiresAt) {
+					oldest = k
+				}
+			}
+
+			delete(oauth2Tokens.tokens, oldest)
+		}
+	}
+
+	oauth2Tokens.tokens[key] = token
+}
+
+// requestOAuth2Token requests an access token to the token endpoint
+func requestOAuth2Token(ctx context.Context, client *http.Client, auth *store.OAuth2Auth) (*OAuth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", auth.GrantType)
+
+	if auth.Scope != "" {
+		form.Set("scope", auth.Scope)
+	}
+
+	if auth.GrantType == enums.OAuth2GrantPassword {
+		form.Set("username", auth.Username)
+		form.Set("password", auth.Password)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token url: %v", err)
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(auth.ClientID), url.QueryEscape(auth.ClientSecret))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not request the access token: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not request the access token: the token endpoint responded with %s", res.Status)
+	}
+
+	token := &OAuth2Token{}
+	if err := json.NewDecoder(res.Body).Decode(token); err != nil {
+		return nil, fmt.Errorf("invalid response from the token endpoint: %v", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("invalid response from the token endpoint: missing access_token")
+	}
+
+	if token.TokenType == "" || strings.EqualFold(token.TokenType, "bearer") {
+		token.TokenType = "Bearer"
+	}
+
+	// tokens without expiration are cached for an hour
+	expiresIn := time.Hour
+	if token.ExpiresIn > 0 {
+		expiresIn = time.Duration(token.ExpiresIn) * time.Second
+	}
+
+	token.ExpiresAt = time.Now().Add(expiresIn - oauth2ExpiryMargin)
+
+	return token, nil
+}
+
+// oauth2CacheKey returns the hash of the settings, so the secrets are not kept as map keys
+func oauth2CacheKey(auth *store.OAuth2Auth) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		auth.GrantType,
+		auth.TokenURL,
+		auth.ClientID,
+		auth.ClientSecret,
+		auth.Username,
+		auth.Password,
+		auth.Scope,
+	}, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
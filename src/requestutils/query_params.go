@@ -0,0 +1,85 @@
+package requestutils
+
+import (
+	"strings"
+
+	"apiboy/backend/src/store"
+)
+
+// The query params are kept as they are written in the url, without decoding them,
+// so the variables like {{name}} and the encoding chosen by the user are preserved.
+
+// splitURL returns the parts of a url before the query string, the query string and the fragment
+func splitURL(rawURL string) (string, string, string) {
+	fragment := ""
+	if i := strings.Index(rawURL, "#"); i >= 0 {
+		rawURL, fragment = rawURL[:i], rawURL[i:]
+	}
+
+	query := ""
+	if i := strings.Index(rawURL, "?"); i >= 0 {
+		rawURL, query = rawURL[:i], rawURL[i+1:]
+	}
+
+	return rawURL, query, fragment
+}
+
+// ParseQueryParams returns the query params of a url as enabled params. The descriptions of the
+// previous params with the same keys are kept, and the previous disabled params are added at the end.
+func ParseQueryParams(rawURL string, previous []*store.Param) []*store.Param {
+	_, query, _ := splitURL(rawURL)
+
+	params := []*store.Param{}
+	used := map[*store.Param]bool{}
+
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+
+		param := &store.Param{Key: pair, Enabled: true}
+
+		if i := strings.Index(pair, "="); i >= 0 {
+			param.Key, param.Value = pair[:i], pair[i+1:]
+		}
+
+		for _, prev := range previous {
+			if prev != nil && prev.Enabled && prev.Key == param.Key && !used[prev] {
+				param.Description = prev.Description
+				used[prev] = true
+				break
+			}
+		}
+
+		params = append(params, param)
+	}
+
+	for _, prev := range previous {
+		if prev != nil && !prev.Enabled {
+			params = append(params, prev)
+		}
+	}
+
+	return params
+}
+
+// SetQueryParams returns the url with its query string replaced by the enabled params
+func SetQueryParams(rawURL string, params []*store.Param) string {
+	base, _, fragment := splitURL(rawURL)
+
+	pairs := []string{}
+
+	for _, param := range params {
+		if param == nil || !param.Enabled || param.Key == "" {
+			continue
+		}
+
+		pairs = append(pairs, param.Key+"="+param.Value)
+	}
+
+	if len(pairs) == 0 {
+		return base + fragment
+	}
+
+	return base + "?" + strings.Join(pairs, "&") + fragment
+}
@@ -0,0 +1,254 @@
+package requestutils
+
+import (
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+)
+
+// Source is the entity where a resolved value is defined
+type Source struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+}
+
+// ResolvedParam is a header of a resolved request
+type ResolvedParam struct {
+	Key    string  `json:"key"`
+	Value  string  `json:"value"`
+	Source *Source `json:"source"`
+}
+
+// ResolvedVariable is a variable available to a resolved request
+type ResolvedVariable struct {
+	Value  string  `json:"value"`
+	Source *Source `json:"source"`
+}
+
+// ResolvedRequest is a request with the defaults of its folders and project applied
+// and the references to variables replaced
+type ResolvedRequest struct {
+	Method        string                       `json:"method"`
+	URL           string                       `json:"url"`
+	BaseURLSource *Source                      `json:"base_url_source"`
+	Headers       []*ResolvedParam             `json:"headers"`
+	Variables     map[string]*ResolvedVariable `json:"variables"`
+	Auth          *store.Auth                  `json:"auth"`
+	AuthSource    *Source                      `json:"auth_source"`
+}
+
+// VariableValues returns the values of the variables
+func (r *ResolvedRequest) VariableValues() map[string]string {
+	values := map[string]string{}
+
+	for name, variable := range r.Variables {
+		values[name] = variable.Value
+	}
+
+	return values
+}
+
+// Resolve applies the defaults of the folders (from the nearest to the farthest) and the project
+// to a request. The request overrides its folders and the nearest folder overrides the farther
+// ones and the project, except for the variables, where the variables of the environment
+// override the defaults. The environment can be nil.
+func Resolve(request *store.Request, folders []*store.Folder, project *store.Project, environment *store.Environment) *ResolvedRequest {
+	resolved := &ResolvedRequest{
+		Method:    strings.ToUpper(request.Type),
+		Variables: map[string]*ResolvedVariable{},
+	}
+
+	requestSource := &Source{EntityType: enums.EntityTypeRequest, EntityID: request.ID}
+	projectSource := &Source{EntityType: enums.EntityTypeProject, EntityID: project.ID}
+
+	folderSources := []*Source{}
+	for _, folder := range folders {
+		folderSources = append(folderSources, &Source{EntityType: enums.EntityTypeFolder, EntityID: folder.ID})
+	}
+
+	// variables, from the farthest to the nearest
+	setVariables := func(variables map[string]string, source *Source) {
+		for name, value := range variables {
+			resolved.Variables[name] = &ResolvedVariable{Value: value, Source: source}
+		}
+	}
+
+	setVariables(project.Variables, projectSource)
+
+	for i := len(folders) - 1; i >= 0; i-- {
+		setVariables(folders[i].Variables, folderSources[i])
+	}
+
+	if environment != nil {
+		setVariables(environment.Variables, &Source{EntityType: enums.EntityTypeEnvironment, EntityID: environment.ID})
+	}
+
+	variables := resolved.VariableValues()
+
+	// url, the relative urls are appended to the nearest base url
+	url := request.URL
+
+	if isRelativeURL(url) {
+		for i, folder := range folders {
+			if folder.BaseURL != "" {
+				url = joinURL(folder.BaseURL, url)
+				resolved.BaseURLSource = folderSources[i]
+				break
+			}
+		}
+
+		if resolved.BaseURLSource == nil && project.BaseURL != "" {
+			url = joinURL(project.BaseURL, url)
+			resolved.BaseURLSource = projectSource
+		}
+	}
+
+	resolved.URL = ReplaceVariables(url, variables)
+
+	// headers, the names are not case sensitive
+	seen := map[string]bool{}
+
+	addHeaders := func(headers []*store.Param, source *Source) {
+		for _, header := range headers {
+			if header == nil {
+				continue
+			}
+
+			name := strings.ToLower(header.Key)
+
+			if !header.Enabled || header.Key == "" || seen[name] {
+				continue
+			}
+
+			seen[name] = true
+			resolved.Headers = append(resolved.Headers, &ResolvedParam{
+				Key:    ReplaceVariables(header.Key, variables),
+				Value:  ReplaceVariables(header.Value, variables),
+				Source: source,
+			})
+		}
+	}
+
+	addHeaders(request.Headers, requestSource)
+
+	for i, folder := range folders {
+		addHeaders(folder.Headers, folderSources[i])
+	}
+
+	addHeaders(project.Headers, projectSource)
+
+	// auth
+	auths := []*store.Auth{request.Auth}
+	sources := []*Source{requestSource}
+
+	for i, folder := range folders {
+		auths = append(auths, folder.Auth)
+		sources = append(sources, folderSources[i])
+	}
+
+	auths = append(auths, project.Auth)
+	sources = append(sources, projectSource)
+
+	resolved.Auth = AuthWithVariables(ResolveAuth(auths...), variables)
+
+	for i, auth := range auths {
+		if auth != nil && auth.Type != "" && auth.Type != enums.AuthTypeInherit {
+			resolved.AuthSource = sources[i]
+			break
+		}
+	}
+
+	return resolved
+}
+
+// WithDefaults returns a copy of a request with the base url and the headers of its folders (from the
+// nearest to the farthest) and project applied, without replacing the references to variables
+func WithDefaults(request *store.Request, folders []*store.Folder, project *store.Project) *store.Request {
+	r := *request
+
+	if isRelativeURL(r.URL) {
+		baseURL := project.BaseURL
+
+		for i := len(folders) - 1; i >= 0; i-- {
+			if folders[i].BaseURL != "" {
+				baseURL = folders[i].BaseURL
+			}
+		}
+
+		if baseURL != "" {
+			r.URL = joinURL(baseURL, r.URL)
+		}
+	}
+
+	r.Headers = []*store.Param{}
+	seen := map[string]bool{}
+
+	for _, header := range request.Headers {
+		if header == nil {
+			continue
+		}
+
+		r.Headers = append(r.Headers, header)
+		seen[strings.ToLower(header.Key)] = header.Enabled || seen[strings.ToLower(header.Key)]
+	}
+
+	defaults := []*store.Param{}
+	for _, folder := range folders {
+		defaults = append(defaults, folder.Headers...)
+	}
+	defaults = append(defaults, project.Headers...)
+
+	for _, header := range defaults {
+		if header == nil {
+			continue
+		}
+
+		name := strings.ToLower(header.Key)
+
+		if header.Enabled && header.Key != "" && !seen[name] {
+			seen[name] = true
+			r.Headers = append(r.Headers, header)
+		}
+	}
+
+	return &r
+}
+
+// isRelativeURL returns if a url does not include the scheme, start with a host and port
+// like localhost:8080, or start with a variable
+func isRelativeURL(url string) bool {
+	if strings.HasPrefix(url, "{{") {
+		return false
+	}
+
+	if end := strings.IndexAny(url, "/?#"); end >= 0 {
+		url = url[:end]
+	}
+
+	i := strings.LastIndex(url, ":")
+	if i < 0 {
+		return true
+	}
+
+	// the scheme ends with the colon, and the port can be a variable
+	port := url[i+1:]
+	if port == "" || strings.HasPrefix(port, "{{") {
+		return false
+	}
+
+	return strings.Trim(port, "0123456789") != ""
+}
+
+// joinURL joins a base url and a relative url with a single slash
+func joinURL(base, url string) string {
+	if url == "" {
+		return base
+	}
+
+	if strings.HasPrefix(url, "?") || strings.HasPrefix(url, "#") {
+		return strings.TrimSuffix(base, "/") + url
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(url, "/")
+}
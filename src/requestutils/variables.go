@@ -0,0 +1,26 @@
+package requestutils
+
+import (
+	"regexp"
+)
+
+// variablePattern matches the references to variables like {{name}}
+var variablePattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// ReplaceVariables replaces the references to variables with their values,
+// the references to unknown variables are kept as they are
+func ReplaceVariables(s string, variables map[string]string) string {
+	if len(variables) == 0 {
+		return s
+	}
+
+	return variablePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := variablePattern.FindStringSubmatch(ref)[1]
+
+		if value, ok := variables[name]; ok {
+			return value
+		}
+
+		return ref
+	})
+}
@@ -0,0 +1,244 @@
+package scripts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/store"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	// maxLogs is the maximum number of lines logged by the scripts of an execution
+	maxLogs = 100
+
+	// maxLogLength is the maximum length of a line logged by a script
+	maxLogLength = 1000
+)
+
+// setAPI adds the apiboy object and the console to a runtime:
+//
+//	apiboy.environment.get(name), .set(name, value), .unset(name)
+//	apiboy.variables.get(name)
+//	apiboy.request.method, .url, .body, .headers.get(name), .set(name, value), .remove(name)
+//	apiboy.response.status, .time, .body, .json(), .headers.get(name) (only in the test scripts)
+//	apiboy.test(name, fn), apiboy.assert(condition, message)
+//	console.log(...values)
+func (s *Sandbox) setAPI(vm *goja.Runtime, c *Context) *goja.Object {
+	apiboy := vm.NewObject()
+
+	// environment variables, the changes are shared by the scripts of the context
+	environment := vm.NewObject()
+	environment.Set("get", func(name string) goja.Value {
+		if value, ok := c.Environment[name]; ok {
+			return vm.ToValue(value)
+		}
+
+		return goja.Undefined()
+	})
+	environment.Set("set", func(name string, value goja.Value) {
+		c.SetEnvironmentVariable(name, valueString(value))
+	})
+	environment.Set("unset", func(name string) {
+		delete(c.Environment, name)
+		c.Changes[name] = nil
+	})
+	apiboy.Set("environment", environment)
+
+	// variables of the request, the environment variables and the data of the iteration override them
+	variables := vm.NewObject()
+	variables.Set("get", func(name string) goja.Value {
+		if value, ok := c.Data[name]; ok {
+			return vm.ToValue(value)
+		}
+
+		if value, ok := c.Environment[name]; ok {
+			return vm.ToValue(value)
+		} else if _, unset := c.Changes[name]; unset {
+			return goja.Undefined()
+		}
+
+		if value, ok := c.Variables[name]; ok {
+			return vm.ToValue(value)
+		}
+
+		return goja.Undefined()
+	})
+	apiboy.Set("variables", variables)
+
+	// outgoing request, its properties are read back after the pre-request scripts
+	request := vm.NewObject()
+	request.Set("method", c.Request.Method)
+	request.Set("url", c.Request.URL)
+	request.Set("body", c.Request.Body)
+	request.Set("headers", headersObject(vm, func() []*store.Param { return c.Request.Headers }, func(headers []*store.Param) {
+		c.Request.Headers = headers
+	}))
+	apiboy.Set("request", request)
+
+	// response, only in the test scripts
+	if c.Response != nil {
+		response := vm.NewObject()
+		response.Set("status", c.Response.StatusCode)
+		response.Set("time", c.Response.Time)
+		response.Set("body", c.Response.Body)
+		response.Set("json", func() goja.Value {
+			var value interface{}
+			if err := json.Unmarshal([]byte(c.Response.Body), &value); err != nil {
+				panic(vm.NewGoError(fmt.Errorf("the body is not valid JSON: %v", err)))
+			}
+
+			return vm.ToValue(value)
+		})
+		response.Set("headers", headersObject(vm, func() []*store.Param { return c.Response.Headers }, nil))
+		apiboy.Set("response", response)
+	}
+
+	// tests, the exceptions of a test fail the test without stopping the script
+	apiboy.Set("test", func(name string, fn goja.Value) {
+		callable, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(vm.NewTypeError("apiboy.test expects a function"))
+		}
+
+		result := &TestResult{Name: name, Passed: true}
+
+		if _, err := callable(goja.Undefined()); err != nil {
+			// the interruptions of the limits stop the script
+			if interrupted, ok := err.(*goja.InterruptedError); ok {
+				panic(interrupted)
+			}
+
+			result.Passed = false
+			result.Message = exceptionMessage(err)
+		}
+
+		c.Tests = append(c.Tests, result)
+	})
+
+	apiboy.Set("assert", func(condition bool, message goja.Value) {
+		if !condition {
+			text := "Assertion failed"
+			if !goja.IsUndefined(message) {
+				text = valueString(message)
+			}
+
+			panic(vm.NewGoError(fmt.Errorf("%s", text)))
+		}
+	})
+
+	vm.Set("apiboy", apiboy)
+
+	// console, the logs are limited so the scripts can't fill the output
+	console := vm.NewObject()
+	console.Set("log", func(call goja.FunctionCall) goja.Value {
+		values := []string{}
+		for _, arg := range call.Arguments {
+			values = append(values, valueString(arg))
+		}
+
+		line := strings.Join(values, " ")
+		if len(line) > maxLogLength {
+			line = line[:maxLogLength] + "..."
+		}
+
+		if len(c.Logs) < maxLogs {
+			c.Logs = append(c.Logs, line)
+		} else if len(c.Logs) == maxLogs {
+			c.Logs = append(c.Logs, "(the next logs were omitted)")
+		}
+
+		return goja.Undefined()
+	})
+	vm.Set("console", console)
+
+	return request
+}
+
+// headersObject returns an object to read the headers of a request or response, and to change them
+// when the setter is not nil. The names of the headers are not case sensitive.
+func headersObject(vm *goja.Runtime, get func() []*store.Param, set func([]*store.Param)) *goja.Object {
+	headers := vm.NewObject()
+
+	headers.Set("get", func(name string) goja.Value {
+		for _, header := range get() {
+			if header.Enabled && strings.EqualFold(header.Key, name) {
+				return vm.ToValue(header.Value)
+			}
+		}
+
+		return goja.Undefined()
+	})
+
+	if set == nil {
+		return headers
+	}
+
+	remove := func(name string) []*store.Param {
+		kept := []*store.Param{}
+		for _, header := range get() {
+			if !strings.EqualFold(header.Key, name) {
+				kept = append(kept, header)
+			}
+		}
+
+		return kept
+	}
+
+	headers.Set("set", func(name string, value goja.Value) {
+		set(append(remove(name), &store.Param{Key: name, Value: valueString(value), Enabled: true}))
+	})
+	headers.Set("remove", func(name string) {
+		set(remove(name))
+	})
+
+	return headers
+}
+
+// readRequest reads the properties of the request object changed by a pre-request script
+func readRequest(object *goja.Object, request *Request) error {
+	method := strings.ToUpper(valueString(object.Get("method")))
+	if !enums.IsValidRequestType(method) {
+		return fmt.Errorf("invalid method %s", method)
+	}
+
+	request.Method = method
+	request.URL = valueString(object.Get("url"))
+	request.Body = valueString(object.Get("body"))
+
+	return nil
+}
+
+// valueString returns a value as a string, the objects are converted to JSON
+func valueString(value goja.Value) string {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return ""
+	}
+
+	switch value.Export().(type) {
+	case map[string]interface{}, []interface{}:
+		if data, err := json.Marshal(value.Export()); err == nil {
+			return string(data)
+		}
+	}
+
+	return value.String()
+}
+
+// exceptionMessage returns the message of an exception thrown by a script
+func exceptionMessage(err error) string {
+	if exception, ok := err.(*goja.Exception); ok {
+		if object, ok := exception.Value().(*goja.Object); ok {
+			if message := object.Get("message"); message != nil && !goja.IsUndefined(message) {
+				return message.String()
+			}
+		}
+
+		return exception.Value().String()
+	}
+
+	return err.Error()
+}
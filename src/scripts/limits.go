@@ -0,0 +1,182 @@
+package scripts
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	// stringCharSize is the memory in bytes counted for each character of a string
+	stringCharSize = 2
+
+	// arrayItemSize is the memory in bytes counted for each item of an array
+	arrayItemSize = 16
+)
+
+// setLimits wraps the builtins that can create large strings and arrays from small values, so they
+// throw a RangeError instead of allocating more than the maximum memory of the sandbox. The sizes are
+// checked before calling the original builtins, the builtins missing in the engine are skipped,
+// and the array buffers are not available. The values built with operators, like s += s,
+// are limited by the checks of the heap of the watcher.
+func (s *Sandbox) setLimits(vm *goja.Runtime) {
+	maxString := int64(s.MaxMemory / stringCharSize)
+	maxArray := int64(s.MaxMemory / arrayItemSize)
+
+	stringPrototype := prototypeOf(vm, "String")
+	arrayPrototype := prototypeOf(vm, "Array")
+	functionPrototype := prototypeOf(vm, "Function")
+
+	s.wrapBuiltin(vm, stringPrototype, "repeat", maxString, func(this goja.Value, args []goja.Value) int64 {
+		return multiplyLength(lengthOf(vm, this), integerArg(args, 0))
+	})
+	s.wrapBuiltin(vm, stringPrototype, "padStart", maxString, func(this goja.Value, args []goja.Value) int64 {
+		return integerArg(args, 0)
+	})
+	s.wrapBuiltin(vm, stringPrototype, "padEnd", maxString, func(this goja.Value, args []goja.Value) int64 {
+		return integerArg(args, 0)
+	})
+	s.wrapBuiltin(vm, stringPrototype, "concat", maxString, func(this goja.Value, args []goja.Value) int64 {
+		length := lengthOf(vm, this)
+		for _, arg := range args {
+			length += primitiveLength(arg, 0)
+		}
+
+		return length
+	})
+	s.wrapBuiltin(vm, stringPrototype, "replace", maxString, func(this goja.Value, args []goja.Value) int64 {
+		// each match can be replaced by the replacement, or by the matched text when it uses $&
+		if len(args) < 2 {
+			return lengthOf(vm, this)
+		} else if _, ok := goja.AssertFunction(args[1]); ok {
+			return lengthOf(vm, this)
+		}
+
+		return multiplyLength(lengthOf(vm, this), primitiveLength(args[1], 0)+1)
+	})
+
+	s.wrapBuiltin(vm, arrayPrototype, "join", maxString, func(this goja.Value, args []goja.Value) int64 {
+		separator := int64(1)
+		if len(args) > 0 && !goja.IsUndefined(args[0]) {
+			separator = primitiveLength(args[0], 1)
+		}
+
+		return multiplyLength(lengthOf(vm, this), separator)
+	})
+	s.wrapBuiltin(vm, arrayPrototype, "fill", maxArray, func(this goja.Value, args []goja.Value) int64 {
+		return lengthOf(vm, this)
+	})
+	s.wrapBuiltin(vm, arrayPrototype, "concat", maxArray, func(this goja.Value, args []goja.Value) int64 {
+		length := lengthOf(vm, this)
+		for _, arg := range args {
+			if _, ok := arg.(*goja.Object); ok {
+				length += lengthOf(vm, arg)
+			} else {
+				length++
+			}
+		}
+
+		return length
+	})
+	s.wrapBuiltin(vm, vm.Get("Array").ToObject(vm), "from", maxArray, func(this goja.Value, args []goja.Value) int64 {
+		if len(args) == 0 {
+			return 0
+		}
+
+		return lengthOf(vm, args[0])
+	})
+
+	// the arguments of apply are copied to a list, like Math.max.apply(null, new Array(n))
+	s.wrapBuiltin(vm, functionPrototype, "apply", maxArray, func(this goja.Value, args []goja.Value) int64 {
+		if len(args) < 2 {
+			return 0
+		}
+
+		return lengthOf(vm, args[1])
+	})
+
+	// the array buffers allocate their whole size when they are created
+	vm.Set("ArrayBuffer", goja.Undefined())
+}
+
+// wrapBuiltin replaces a function of an object with a function that throws a RangeError when the size of its
+// result, calculated from its arguments, is greater than the maximum size. The wrapper is not enumerable, like the builtins.
+func (s *Sandbox) wrapBuiltin(vm *goja.Runtime, object *goja.Object, name string, max int64, size func(this goja.Value, args []goja.Value) int64) {
+	original, ok := goja.AssertFunction(object.Get(name))
+	if !ok {
+		return
+	}
+
+	wrapper := func(call goja.FunctionCall) goja.Value {
+		if size(call.This, call.Arguments) > max {
+			panic(rangeError(vm, fmt.Sprintf("the script exceeded the memory limit of %d bytes", s.MaxMemory)))
+		}
+
+		result, err := original(call.This, call.Arguments...)
+		if err != nil {
+			panic(err)
+		}
+
+		return result
+	}
+
+	object.DefineDataProperty(name, vm.ToValue(wrapper), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+}
+
+// prototypeOf returns the prototype of a global constructor
+func prototypeOf(vm *goja.Runtime, constructor string) *goja.Object {
+	return vm.Get(constructor).ToObject(vm).Get("prototype").ToObject(vm)
+}
+
+// rangeError returns a new RangeError with a message
+func rangeError(vm *goja.Runtime, msg string) *goja.Object {
+	err, _ := vm.New(vm.Get("RangeError"), vm.ToValue(msg))
+	return err
+}
+
+// lengthOf returns the length of a string, array or array-like object, or 0 if it doesn't have length
+func lengthOf(vm *goja.Runtime, value goja.Value) int64 {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return 0
+	}
+
+	length := value.ToObject(vm).Get("length")
+	if length == nil || goja.IsUndefined(length) {
+		return 0
+	}
+
+	return length.ToInteger()
+}
+
+// primitiveLength returns the length of a primitive value converted to a string, or the default
+// length for the objects, so their toString method is not called twice
+func primitiveLength(value goja.Value, defaultLength int64) int64 {
+	if _, ok := value.(*goja.Object); ok {
+		return defaultLength
+	}
+
+	return int64(len(value.String()))
+}
+
+// integerArg returns an argument as an integer, or 0 if it is missing
+func integerArg(args []goja.Value, i int) int64 {
+	if i >= len(args) {
+		return 0
+	}
+
+	return args[i].ToInteger()
+}
+
+// multiplyLength multiplies two lengths, the results that overflow are the maximum length
+func multiplyLength(a, b int64) int64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+
+	if a > math.MaxInt64/b {
+		return math.MaxInt64
+	}
+
+	return a * b
+}
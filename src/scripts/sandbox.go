@@ -0,0 +1,185 @@
+package scripts
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"apiboy/backend/src/store"
+
+	"github.com/dop251/goja"
+)
+
+// Sandbox runs the scripts of the requests and folders in an embedded JavaScript engine.
+// The scripts don't have access to the network or the filesystem, only to the API of the
+// apiboy object and console.log.
+type Sandbox struct {
+	Timeout   time.Duration
+	MaxMemory uint64
+	slots     chan struct{}
+}
+
+// memoryCheckInterval is the time between the checks of the memory used by a running script
+const memoryCheckInterval = 5 * time.Millisecond
+
+// New returns a new Sandbox, the scripts are interrupted when they run longer than the timeout or
+// they allocate more than the maximum memory, and at most maxConcurrency scripts run at the same time
+func New(timeout time.Duration, maxMemory, maxConcurrency int) *Sandbox {
+	return &Sandbox{
+		Timeout:   timeout,
+		MaxMemory: uint64(maxMemory),
+		slots:     make(chan struct{}, maxConcurrency),
+	}
+}
+
+// Request is the outgoing request of an execution, the pre-request scripts can change it
+type Request struct {
+	Method  string
+	URL     string
+	Headers []*store.Param
+	Body    string
+}
+
+// Response is the response of an execution read by the test scripts, the time is in milliseconds
+type Response struct {
+	StatusCode int
+	Headers    []*store.Param
+	Body       string
+	Time       int64
+}
+
+// TestResult is the result of a test defined by a script with apiboy.test
+type TestResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Context is the state shared by the scripts of an execution. The variables are the resolved variables
+// of the request, and the environment contains the variables of the environment changed by the scripts,
+// which override them. The changes are the variables set or unset (nil) by the scripts, and the data
+// contains the variables of an iteration of a run, which override the environment.
+type Context struct {
+	Variables   map[string]string
+	Data        map[string]string
+	Environment map[string]string
+	Changes     map[string]*string
+	Request     *Request
+	Response    *Response
+	Tests       []*TestResult
+	Logs        []string
+}
+
+// NewContext returns a new Context for an execution, without response
+func NewContext(variables, environment map[string]string, request *Request) *Context {
+	c := &Context{
+		Variables:   variables,
+		Environment: map[string]string{},
+		Changes:     map[string]*string{},
+		Request:     request,
+		Tests:       []*TestResult{},
+		Logs:        []string{},
+	}
+
+	for name, value := range environment {
+		c.Environment[name] = value
+	}
+
+	return c
+}
+
+// Check checks the syntax of a script, the scripts are not run
+func Check(script string) error {
+	_, err := goja.Compile("", script, false)
+	return err
+}
+
+// SetEnvironmentVariable sets a variable of the environment, and records the change
+func (c *Context) SetEnvironmentVariable(name, value string) {
+	c.Environment[name] = value
+	c.Changes[name] = &value
+}
+
+// limitError is the value of the interruptions of the scripts that exceed a limit
+type limitError struct {
+	msg string
+}
+
+// Run runs a script with the API of a context, the name identifies the script in the errors.
+// The scripts of the same context share the changes of the variables and the request,
+// and the test scripts can read the response of the context.
+func (s *Sandbox) Run(name, script string, c *Context) error {
+	program, err := goja.Compile(name, script, false)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	// the scripts wait for a free slot, so the memory used by all of them is limited too
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	vm := goja.New()
+	s.setLimits(vm)
+	request := s.setAPI(vm, c)
+
+	// the script is interrupted when it exceeds the time or memory limits
+	done := make(chan struct{})
+	defer close(done)
+
+	go s.watch(vm, done)
+
+	if _, err := vm.RunProgram(program); err != nil {
+		if interrupted, ok := err.(*goja.InterruptedError); ok {
+			if limit, ok := interrupted.Value().(*limitError); ok {
+				return fmt.Errorf("%s: %s", name, limit.msg)
+			}
+		}
+
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	// the request of the script is read back, so the changes of its properties are applied
+	if c.Response == nil {
+		if err := readRequest(request, c.Request); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// watch interrupts a running script when it exceeds the time limit, or when the heap grows more than
+// the maximum memory since the script started. The heap is shared by the process, so the memory
+// allocated by other goroutines while the script runs is counted too.
+func (s *Sandbox) watch(vm *goja.Runtime, done chan struct{}) {
+	timeout := time.NewTimer(s.Timeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+
+	initial := heapAlloc()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-timeout.C:
+			vm.Interrupt(&limitError{msg: fmt.Sprintf("the script exceeded the time limit of %v", s.Timeout)})
+			return
+		case <-ticker.C:
+			if current := heapAlloc(); current > initial && current-initial > s.MaxMemory {
+				vm.Interrupt(&limitError{msg: fmt.Sprintf("the script exceeded the memory limit of %d bytes", s.MaxMemory)})
+				return
+			}
+		}
+	}
+}
+
+// heapAlloc returns the bytes of the allocated heap objects of the process
+func heapAlloc() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
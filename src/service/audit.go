@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"apiboy/backend/src/diffutils"
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+)
+
+// auditIgnoredFields are the fields that are not included in the changes of the audit entries,
+// the iterations and results of the runs are not part of their documents
+var auditIgnoredFields = []string{"updated", "iterations", "results"}
+
+// recordAuditEntry saves an entry in the audit log for a change done by the authenticated user,
+// the before and after values are the states of the entity (nil if it did not exist)
+func (s *Service) recordAuditEntry(ctx context.Context, action, entityType string, before, after interface{}) error {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	before, after = redactAuditEntity(before), redactAuditEntity(after)
+
+	changes, err := diffutils.Diff(before, after, auditIgnoredFields...)
+	if err != nil {
+		return err
+	}
+
+	entity := after
+	if entity == nil {
+		entity = before
+	}
+
+	entry := &store.AuditEntry{
+		ID:         s.Store.NewAuditEntryID(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   getStringField(entity, "ID"),
+		ProjectID:  getStringField(entity, "ProjectID"),
+		IP:         httputils.GetContextClientIP(ctx, s.Config.TrustedProxies),
+		Changes:    changes,
+	}
+
+	if entityType == enums.EntityTypeProject {
+		entry.ProjectID = entry.EntityID
+	}
+
+	return s.Store.CreateAuditEntry(ctx, authData.UserID, entry)
+}
+
+// getAuditEntity gets the current state of an entity referenced by the input of an endpoint,
+// it returns nil if the input does not reference an existing entity
+func (s *Service) getAuditEntity(ctx context.Context, entityType string, input interface{}) (interface{}, error) {
+	id := getStringField(input, "ID")
+
+	if entityType == enums.EntityTypeProjectUser && id == "" {
+		projectID := getStringField(input, "ProjectID")
+		userID := getStringField(input, "UserID")
+
+		if projectID != "" && userID != "" {
+			id = s.Store.NewProjectUserID(projectID, userID)
+		}
+	}
+
+	if id == "" {
+		if entityType != enums.EntityTypeUser {
+			return nil, nil
+		}
+
+		// the user endpoints modify the authenticated user by default
+		id = httputils.GetContextAuthData(ctx).UserID
+	}
+
+	var entity interface{}
+	var err error
+
+	switch entityType {
+	case enums.EntityTypeUser:
+		entity, err = s.Store.GetUserByID(ctx, id)
+	case enums.EntityTypeProject:
+		entity, err = s.Store.GetProjectByID(ctx, id)
+	case enums.EntityTypeProjectUser:
+		entity, err = s.Store.GetProjectUserByID(ctx, id)
+	case enums.EntityTypeFolder:
+		entity, err = s.Store.GetFolderByID(ctx, id)
+	case enums.EntityTypeRequest:
+		entity, err = s.Store.GetRequestByID(ctx, id)
+	case enums.EntityTypeEnvironment:
+		entity, err = s.Store.GetEnvironmentByID(ctx, id)
+	case enums.EntityTypeResponse:
+		entity, err = s.Store.GetResponseByID(ctx, id)
+	case enums.EntityTypeRun:
+		entity, err = s.Store.GetRunByID(ctx, id)
+	}
+
+	if err != nil || isNil(entity) {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// redactAuditEntity returns a copy of an entity without the secrets of its auth, so they are not saved in the audit log
+func redactAuditEntity(entity interface{}) interface{} {
+	switch e := entity.(type) {
+	case *store.Request:
+		if e != nil {
+			copy := *e
+			copy.Auth = e.Auth.Redacted()
+			return &copy
+		}
+	case *store.Folder:
+		if e != nil {
+			copy := *e
+			copy.Auth = e.Auth.Redacted()
+			return &copy
+		}
+	case *store.Project:
+		if e != nil {
+			copy := *e
+			copy.Auth = e.Auth.Redacted()
+			return &copy
+		}
+	}
+
+	return entity
+}
+
+// getOutputEntity returns the first entity included in the output of an endpoint
+func getOutputEntity(output interface{}) interface{} {
+	v := reflect.Indirect(reflect.ValueOf(output))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct {
+			return field.Interface()
+		}
+	}
+
+	return nil
+}
+
+// getStringField returns the value of a string field of a struct, or an empty string if not found
+func getStringField(obj interface{}, name string) string {
+	if isNil(obj) {
+		return ""
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := v.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
+
+// isNil checks if an interface is nil or contains a nil pointer
+func isNil(obj interface{}) bool {
+	if obj == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(obj)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
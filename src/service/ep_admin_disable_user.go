@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminDisableUserInput is the input of the endpoint
+type AdminDisableUserInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AdminDisableUserOutput is the output of the endpoint
+type AdminDisableUserOutput struct {
+	User *store.User `json:"user"`
+}
+
+// AdminDisableUser implements the business logic for the endpoint
+func (s *Service) AdminDisableUser(ctx context.Context, input *AdminDisableUserInput) (*AdminDisableUserOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	if input.ID == authData.UserID {
+		return nil, errors.BadRequest{Msg: "An administrator can not disable itself"}
+	}
+
+	// get user
+	user, err := s.Store.GetUserByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get user", Err: err}
+	} else if user == nil {
+		return nil, errors.NotFound{Obj: "User"}
+	}
+
+	// disable user
+	user.Disabled = true
+
+	if err = s.Store.UpdateUser(ctx, authData.UserID, user); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not disable user", Err: err}
+	}
+
+	// close all the sessions of the user
+	if err = s.Store.DeleteTokensByUserID(ctx, user.ID); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not delete tokens", Err: err}
+	}
+
+	return &AdminDisableUserOutput{
+		User: user,
+	}, nil
+}
+
+// MakeAdminDisableUserEndpoint creates the endpoint
+func MakeAdminDisableUserEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminDisableUserInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminDisableUser(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminEnableUserInput is the input of the endpoint
+type AdminEnableUserInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AdminEnableUserOutput is the output of the endpoint
+type AdminEnableUserOutput struct {
+	User *store.User `json:"user"`
+}
+
+// AdminEnableUser implements the business logic for the endpoint
+func (s *Service) AdminEnableUser(ctx context.Context, input *AdminEnableUserInput) (*AdminEnableUserOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get user
+	user, err := s.Store.GetUserByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get user", Err: err}
+	} else if user == nil {
+		return nil, errors.NotFound{Obj: "User"}
+	}
+
+	// enable user
+	user.Disabled = false
+
+	if err = s.Store.UpdateUser(ctx, authData.UserID, user); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not enable user", Err: err}
+	}
+
+	return &AdminEnableUserOutput{
+		User: user,
+	}, nil
+}
+
+// MakeAdminEnableUserEndpoint creates the endpoint
+func MakeAdminEnableUserEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminEnableUserInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminEnableUser(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
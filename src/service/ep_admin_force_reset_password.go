@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+)
+
+// AdminForceResetPasswordInput is the input of the endpoint
+type AdminForceResetPasswordInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AdminForceResetPasswordOutput is the output of the endpoint
+type AdminForceResetPasswordOutput struct {
+	User *store.User `json:"user"`
+}
+
+// AdminForceResetPassword implements the business logic for the endpoint
+func (s *Service) AdminForceResetPassword(ctx context.Context, input *AdminForceResetPasswordInput) (*AdminForceResetPasswordOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get user
+	user, err := s.Store.GetUserByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get user", Err: err}
+	} else if user == nil {
+		return nil, errors.NotFound{Obj: "User"}
+	}
+
+	// require a new password the next time the user logs in
+	user.TempCode = uuid.New().String()
+	user.PasswordResetRequired = true
+
+	if err = s.Store.UpdateUser(ctx, authData.UserID, user); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not generate temp password", Err: err}
+	}
+
+	// close all the sessions of the user
+	if err = s.Store.DeleteTokensByUserID(ctx, user.ID); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not delete tokens", Err: err}
+	}
+
+	return &AdminForceResetPasswordOutput{
+		User: user,
+	}, nil
+}
+
+// MakeAdminForceResetPasswordEndpoint creates the endpoint
+func MakeAdminForceResetPasswordEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminForceResetPasswordInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminForceResetPassword(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminGetAuditInput is the input of the endpoint
+type AdminGetAuditInput struct {
+	ActorID    string `json:"actor_id" validate:"-"`
+	Action     string `json:"action" validate:"-"`
+	EntityType string `json:"entity_type" validate:"omitempty,entity_type"`
+	EntityID   string `json:"entity_id" validate:"-"`
+	ProjectID  string `json:"project_id" validate:"-"`
+	From       string `json:"from" validate:"omitempty,time_rfc3339"`
+	To         string `json:"to" validate:"omitempty,time_rfc3339"`
+	Cursor     string `json:"cursor" validate:"-"`
+	Limit      int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// AdminGetAuditOutput is the output of the endpoint
+type AdminGetAuditOutput struct {
+	Entries    []*store.AuditEntry `json:"entries"`
+	NextCursor string              `json:"next_cursor"`
+}
+
+// AdminGetAudit implements the business logic for the endpoint
+func (s *Service) AdminGetAudit(ctx context.Context, input *AdminGetAuditInput) (*AdminGetAuditOutput, error) {
+	if input.Limit == 0 {
+		input.Limit = 20
+	}
+
+	filter := &store.AuditFilter{
+		ActorID:    input.ActorID,
+		Action:     input.Action,
+		EntityType: input.EntityType,
+		EntityID:   input.EntityID,
+		ProjectID:  input.ProjectID,
+	}
+
+	if input.From != "" {
+		from, _ := time.Parse(time.RFC3339, input.From)
+		filter.From = &from
+	}
+
+	if input.To != "" {
+		to, _ := time.Parse(time.RFC3339, input.To)
+		filter.To = &to
+	}
+
+	// get audit entries matching the filters
+	entries, nextCursor, err := s.Store.GetAuditEntries(ctx, filter, input.Cursor, input.Limit)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get audit entries", Err: err}
+	}
+
+	return &AdminGetAuditOutput{
+		Entries:    entries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// MakeAdminGetAuditEndpoint creates the endpoint
+func MakeAdminGetAuditEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminGetAuditInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminGetAudit(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminGetUserInput is the input of the endpoint
+type AdminGetUserInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// AdminGetUserOutput is the output of the endpoint
+type AdminGetUserOutput struct {
+	User     *store.User      `json:"user"`
+	Projects []*store.Project `json:"projects"`
+	Sessions []*store.Token   `json:"sessions"`
+}
+
+// AdminGetUser implements the business logic for the endpoint
+func (s *Service) AdminGetUser(ctx context.Context, input *AdminGetUserInput) (*AdminGetUserOutput, error) {
+	// get user
+	user, err := s.Store.GetUserByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get user", Err: err}
+	} else if user == nil {
+		return nil, errors.NotFound{Obj: "User"}
+	}
+
+	// get the projects of the user
+	projectUsers, err := s.Store.GetProjectUsersByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get projectUsers", Err: err}
+	}
+
+	projects := []*store.Project{}
+
+	for _, projectUser := range projectUsers {
+		project, err := s.Store.GetProjectByID(ctx, projectUser.ProjectID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+		} else if project != nil {
+			projects = append(projects, project)
+		}
+	}
+
+	// get the sessions of the user
+	sessions, err := s.Store.GetTokensByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get tokens", Err: err}
+	}
+
+	return &AdminGetUserOutput{
+		User:     user,
+		Projects: projects,
+		Sessions: sessions,
+	}, nil
+}
+
+// MakeAdminGetUserEndpoint creates the endpoint
+func MakeAdminGetUserEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminGetUserInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminGetUser(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminSearchUsersInput is the input of the endpoint
+type AdminSearchUsersInput struct {
+	Query  string `json:"query" validate:"-"`
+	Cursor string `json:"cursor" validate:"-"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// AdminSearchUsersOutput is the output of the endpoint
+type AdminSearchUsersOutput struct {
+	Users      []*store.User `json:"users"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// AdminSearchUsers implements the business logic for the endpoint
+func (s *Service) AdminSearchUsers(ctx context.Context, input *AdminSearchUsersInput) (*AdminSearchUsersOutput, error) {
+	if input.Limit == 0 {
+		input.Limit = 20
+	}
+
+	// search users by email
+	users, nextCursor, err := s.Store.SearchUsers(ctx, strings.TrimSpace(input.Query), input.Cursor, input.Limit)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not search users", Err: err}
+	}
+
+	return &AdminSearchUsersOutput{
+		Users:      users,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// MakeAdminSearchUsersEndpoint creates the endpoint
+func MakeAdminSearchUsersEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminSearchUsersInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminSearchUsers(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// AdminSetUserRoleInput is the input of the endpoint
+type AdminSetUserRoleInput struct {
+	ID   string `json:"id" validate:"required"`
+	Role string `json:"role" validate:"required,user_role"`
+}
+
+// AdminSetUserRoleOutput is the output of the endpoint
+type AdminSetUserRoleOutput struct {
+	User *store.User `json:"user"`
+}
+
+// AdminSetUserRole implements the business logic for the endpoint
+func (s *Service) AdminSetUserRole(ctx context.Context, input *AdminSetUserRoleInput) (*AdminSetUserRoleOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	if input.ID == authData.UserID {
+		return nil, errors.BadRequest{Msg: "An administrator can not change its own role"}
+	}
+
+	// get user
+	user, err := s.Store.GetUserByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get user", Err: err}
+	} else if user == nil {
+		return nil, errors.NotFound{Obj: "User"}
+	}
+
+	// promote or demote user
+	user.Role = input.Role
+
+	if err = s.Store.UpdateUser(ctx, authData.UserID, user); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not update user", Err: err}
+	}
+
+	// close all the sessions of the user, because the role is part of the jwt claims
+	if err = s.Store.DeleteTokensByUserID(ctx, user.ID); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not delete tokens", Err: err}
+	}
+
+	return &AdminSetUserRoleOutput{
+		User: user,
+	}, nil
+}
+
+// MakeAdminSetUserRoleEndpoint creates the endpoint
+func MakeAdminSetUserRoleEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*AdminSetUserRoleInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.AdminSetUserRole(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
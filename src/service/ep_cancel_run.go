@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// CancelRunInput is the input of the endpoint
+type CancelRunInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// CancelRunOutput is the output of the endpoint
+type CancelRunOutput struct {
+	Run *store.Run `json:"run"`
+}
+
+// CancelRun implements the business logic for the endpoint
+func (s *Service) CancelRun(ctx context.Context, input *CancelRunInput) (*CancelRunOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get run
+	run, err := s.Store.GetRunByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get run", Err: err}
+	} else if run == nil {
+		return nil, errors.NotFound{Obj: "Run"}
+	}
+
+	// check if the user has access to the project of the run
+	if err := s.checkAccessToProject(ctx, authData.UserID, run.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// finish the run if the instance executing it stopped
+	run, err = s.finishStaleRun(ctx, run)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not finish run", Err: err}
+	}
+
+	if enums.IsFinishedRunStatus(run.Status) {
+		return nil, errors.BadRequest{Msg: "The run is already finished"}
+	}
+
+	// the run is cancelled in the store first, so the workers of other instances stop after the current request
+	run, err = s.Store.PatchRun(ctx, run.ID, func(run *store.Run) {
+		if !enums.IsFinishedRunStatus(run.Status) {
+			run.Status = enums.RunStatusCancelled
+			run.Finished = store.NewEvent(authData.UserID)
+		}
+	})
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not cancel run", Err: err}
+	}
+
+	s.runs.cancel(run.ID)
+
+	// get the results of the run
+	results, err := s.Store.GetRunResults(ctx, run.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get run results", Err: err}
+	}
+
+	run.SetResults(results)
+
+	return &CancelRunOutput{
+		Run: run,
+	}, nil
+}
+
+// MakeCancelRunEndpoint creates the endpoint
+func MakeCancelRunEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*CancelRunInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.CancelRun(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// CopyEnvironmentInput is the input of the endpoint
+type CopyEnvironmentInput struct {
+	ID        string `json:"id" validate:"required"`
+	ProjectID string `json:"project_id" validate:"required"`
+}
+
+// CopyEnvironmentOutput is the output of the endpoint
+type CopyEnvironmentOutput struct {
+	Environment *store.Environment `json:"environment"`
+}
+
+// CopyEnvironment implements the business logic for the endpoint
+func (s *Service) CopyEnvironment(ctx context.Context, input *CopyEnvironmentInput) (*CopyEnvironmentOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get environment
+	environment, err := s.Store.GetEnvironmentByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+	} else if environment == nil {
+		return nil, errors.NotFound{Obj: "Environment"}
+	}
+
+	// check if the user has access to the project of the environment and to the target project
+	if err := s.checkAccessToProject(ctx, authData.UserID, environment.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// copy environment
+	environment.ID = s.Store.NewEnvironmentID()
+	environment.ProjectID = input.ProjectID
+	environment.Updated = nil
+
+	if err = s.Store.CreateEnvironment(ctx, authData.UserID, environment); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not copy environment", Err: err}
+	}
+
+	return &CopyEnvironmentOutput{
+		Environment: environment,
+	}, nil
+}
+
+// MakeCopyEnvironmentEndpoint creates the endpoint
+func MakeCopyEnvironmentEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*CopyEnvironmentInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.CopyEnvironment(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// CopyFolderInput is the input of the endpoint, the folder is copied with its subfolders and requests
+// to a parent folder of a project, which can be another project. An empty parent folder is the root of the project.
+type CopyFolderInput struct {
+	ID             string `json:"id" validate:"required"`
+	ProjectID      string `json:"project_id" validate:"required"`
+	ParentFolderID string `json:"parent_folder_id" validate:"-"`
+}
+
+// CopyFolderOutput is the output of the endpoint
+type CopyFolderOutput struct {
+	Folder *store.Folder `json:"folder"`
+}
+
+// CopyFolder implements the business logic for the endpoint
+func (s *Service) CopyFolder(ctx context.Context, input *CopyFolderInput) (*CopyFolderOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder and to the target project
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// check if the copy of the folder with its subfolders fits in the parent folder
+	tree, err := s.getFolderTree(ctx, folder.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getParentChain(ctx, input.ProjectID, input.ParentFolderID, tree.height(folder)); err != nil {
+		return nil, err
+	}
+
+	// the copy is placed at the end of the parent folder
+	rank, err := s.rankFolder(ctx, authData.UserID, input.ProjectID, input.ParentFolderID, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// copy the folder with its subfolders and requests, the files
+	// of the body of the requests are copied to the project
+	folders := tree.subtree(folder)
+	requests := tree.subtreeRequests(folders)
+
+	copied, err := s.copyRequestBlobs(ctx, authData.UserID, input.ProjectID, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cloneFolderTree(folders, requests, input.ProjectID, input.ParentFolderID)
+
+	folder.Rank = rank
+
+	if err = s.Store.CreateFolderTree(ctx, authData.UserID, folders, requests); err != nil {
+		s.deleteBlobs(ctx, copied)
+		return nil, errors.InternalServer{Msg: "Could not copy folder", Err: err}
+	}
+
+	return &CopyFolderOutput{
+		Folder: folder,
+	}, nil
+}
+
+// MakeCopyFolderEndpoint creates the endpoint
+func MakeCopyFolderEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*CopyFolderInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.CopyFolder(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// CopyRequestInput is the input of the endpoint, the folder can be a folder of another project
+type CopyRequestInput struct {
+	ID       string `json:"id" validate:"required"`
+	FolderID string `json:"folder_id" validate:"required"`
+}
+
+// CopyRequestOutput is the output of the endpoint
+type CopyRequestOutput struct {
+	Request *store.Request `json:"request"`
+}
+
+// CopyRequest implements the business logic for the endpoint
+func (s *Service) CopyRequest(ctx context.Context, input *CopyRequestInput) (*CopyRequestOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.FolderID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// the copy is placed at the end of the folder
+	rank, err := s.rankRequest(ctx, authData.UserID, folder.ID, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// the files of the body are copied to the project of the folder
+	copied, err := s.copyRequestBlobs(ctx, authData.UserID, folder.ProjectID, []*store.Request{request})
+	if err != nil {
+		return nil, err
+	}
+
+	// copy request
+	request.ID = s.Store.NewRequestID()
+	request.FolderID = folder.ID
+	request.ProjectID = folder.ProjectID
+	request.Rank = rank
+	request.Updated = nil
+
+	if err = s.Store.CreateRequest(ctx, authData.UserID, request); err != nil {
+		s.deleteBlobs(ctx, copied)
+		return nil, errors.InternalServer{Msg: "Could not copy request", Err: err}
+	}
+
+	return &CopyRequestOutput{
+		Request: request,
+	}, nil
+}
+
+// MakeCopyRequestEndpoint creates the endpoint
+func MakeCopyRequestEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*CopyRequestInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.CopyRequest(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
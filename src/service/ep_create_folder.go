@@ -13,8 +13,15 @@ import (
 
 // CreateFolderInput is the input of the endpoint
 type CreateFolderInput struct {
-	Name      string `json:"name" validate:"required"`
-	ProjectID string `json:"project_id" validate:"required"`
+	Name             string            `json:"name" validate:"required"`
+	ProjectID        string            `json:"project_id" validate:"required"`
+	ParentFolderID   string            `json:"parent_folder_id" validate:"-"`
+	BaseURL          string            `json:"base_url" validate:"-"`
+	Headers          []*store.Param    `json:"headers" validate:"-"`
+	Variables        map[string]string `json:"variables" validate:"-"`
+	Auth             *store.Auth       `json:"auth" validate:"-"`
+	PreRequestScript string            `json:"pre_request_script" validate:"-"`
+	TestScript       string            `json:"test_script" validate:"-"`
 }
 
 // CreateFolderOutput is the output of the endpoint
@@ -32,11 +39,45 @@ func (s *Service) CreateFolder(ctx context.Context, input *CreateFolderInput) (*
 		return nil, err
 	}
 
+	// check if the parent folder is a folder of the project with room for a subfolder (if included)
+	if _, err := s.getParentChain(ctx, input.ProjectID, input.ParentFolderID, 1); err != nil {
+		return nil, err
+	}
+
+	// the folder is placed at the end of the parent folder
+	rank, err := s.rankFolder(ctx, authData.UserID, input.ProjectID, input.ParentFolderID, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	// check the auth settings
+	if err := checkAuth(input.Auth, true); err != nil {
+		return nil, err
+	}
+
+	// check the scripts
+	if err := checkScripts(&input.PreRequestScript, &input.TestScript); err != nil {
+		return nil, err
+	}
+
 	// create folder
 	folder := &store.Folder{
-		ID:        s.Store.NewFolderID(),
-		Name:      strings.TrimSpace(input.Name),
-		ProjectID: input.ProjectID,
+		ID:               s.Store.NewFolderID(),
+		Name:             strings.TrimSpace(input.Name),
+		ProjectID:        input.ProjectID,
+		ParentFolderID:   input.ParentFolderID,
+		Rank:             rank,
+		BaseURL:          input.BaseURL,
+		Headers:          input.Headers,
+		Variables:        input.Variables,
+		Auth:             input.Auth,
+		PreRequestScript: input.PreRequestScript,
+		TestScript:       input.TestScript,
 	}
 
 	if err := s.Store.CreateFolder(ctx, authData.UserID, folder); err != nil {
@@ -13,7 +13,11 @@ import (
 
 // CreateProjectInput is the input of the endpoint
 type CreateProjectInput struct {
-	Name string `json:"name" validate:"required"`
+	Name      string            `json:"name" validate:"required"`
+	BaseURL   string            `json:"base_url" validate:"-"`
+	Headers   []*store.Param    `json:"headers" validate:"-"`
+	Variables map[string]string `json:"variables" validate:"-"`
+	Auth      *store.Auth       `json:"auth" validate:"-"`
 }
 
 // CreateProjectOutput is the output of the endpoint
@@ -26,10 +30,24 @@ func (s *Service) CreateProject(ctx context.Context, input *CreateProjectInput)
 	// get the auth data from the context
 	authData := httputils.GetContextAuthData(ctx)
 
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	// check the auth settings
+	if err := checkAuth(input.Auth, false); err != nil {
+		return nil, err
+	}
+
 	// create project
 	project := &store.Project{
-		ID:   s.Store.NewProjectID(),
-		Name: strings.TrimSpace(input.Name),
+		ID:        s.Store.NewProjectID(),
+		Name:      strings.TrimSpace(input.Name),
+		BaseURL:   input.BaseURL,
+		Headers:   input.Headers,
+		Variables: input.Variables,
+		Auth:      input.Auth,
 	}
 
 	if err := s.Store.CreateProject(ctx, authData.UserID, project); err != nil {
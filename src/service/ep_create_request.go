@@ -4,8 +4,10 @@ import (
 	"context"
 	"strings"
 
+	"apiboy/backend/src/enums"
 	"apiboy/backend/src/errors"
 	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/requestutils"
 	"apiboy/backend/src/store"
 
 	"github.com/go-kit/kit/endpoint"
@@ -13,12 +15,24 @@ import (
 
 // CreateRequestInput is the input of the endpoint
 type CreateRequestInput struct {
-	Name     string            `json:"name" validate:"required"`
-	FolderID string            `json:"folder_id" validate:"required"`
-	Type     string            `json:"type" validate:"omitempty,request_type"`
-	URL      string            `json:"url" validate:"-"`
-	Headers  map[string]string `json:"headers" validate:"-"`
-	Body     string            `json:"body" validate:"-"`
+	Name             string                 `json:"name" validate:"required"`
+	FolderID         string                 `json:"folder_id" validate:"required"`
+	Type             string                 `json:"type" validate:"omitempty,request_type"`
+	URL              string                 `json:"url" validate:"-"`
+	QueryParams      []*store.Param         `json:"query_params" validate:"-"`
+	Headers          []*store.Param         `json:"headers" validate:"-"`
+	BodyMode         string                 `json:"body_mode" validate:"omitempty,body_mode"`
+	Body             string                 `json:"body" validate:"-"`
+	BodyContentType  string                 `json:"body_content_type" validate:"-"`
+	FormParams       []*store.Param         `json:"form_params" validate:"-"`
+	MultipartParts   []*store.MultipartPart `json:"multipart_parts" validate:"-"`
+	BinaryBlobID     string                 `json:"binary_blob_id" validate:"-"`
+	GraphQL          *store.GraphQLBody     `json:"graphql" validate:"-"`
+	Auth             *store.Auth            `json:"auth" validate:"-"`
+	Assertions       []*store.Assertion     `json:"assertions" validate:"-"`
+	Captures         []*store.Capture       `json:"captures" validate:"-"`
+	PreRequestScript string                 `json:"pre_request_script" validate:"-"`
+	TestScript       string                 `json:"test_script" validate:"-"`
 }
 
 // CreateRequestOutput is the output of the endpoint
@@ -44,16 +58,86 @@ func (s *Service) CreateRequest(ctx context.Context, input *CreateRequestInput)
 		return nil, err
 	}
 
+	// check the form params
+	if err := checkParams(input.FormParams, "form param"); err != nil {
+		return nil, err
+	}
+
+	// check the files and the GraphQL variables of the body
+	if err := s.checkRequestBody(ctx, folder.ProjectID, input.MultipartParts, input.BinaryBlobID, input.GraphQL); err != nil {
+		return nil, err
+	}
+
+	// the request is placed at the end of the folder
+	rank, err := s.rankRequest(ctx, authData.UserID, folder.ID, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// check the query params
+	if err := checkParams(input.QueryParams, "query param"); err != nil {
+		return nil, err
+	}
+
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	// check the auth settings
+	if err := checkAuth(input.Auth, true); err != nil {
+		return nil, err
+	}
+
+	// check the assertions
+	if err := checkAssertions(input.Assertions); err != nil {
+		return nil, err
+	}
+
+	// check the captures
+	if err := checkCaptures(input.Captures); err != nil {
+		return nil, err
+	}
+
+	// check the scripts
+	if err := checkScripts(&input.PreRequestScript, &input.TestScript); err != nil {
+		return nil, err
+	}
+
+	if input.BodyMode == "" {
+		input.BodyMode = enums.BodyModeRaw
+	}
+
+	// the query params replace the query string of the url, or they are parsed from it when not included
+	if input.QueryParams != nil {
+		input.URL = requestutils.SetQueryParams(input.URL, input.QueryParams)
+	} else {
+		input.QueryParams = requestutils.ParseQueryParams(input.URL, nil)
+	}
+
 	// create request
 	request := &store.Request{
-		ID:        s.Store.NewRequestID(),
-		Name:      strings.TrimSpace(input.Name),
-		FolderID:  input.FolderID,
-		ProjectID: folder.ProjectID,
-		Type:      input.Type,
-		URL:       input.URL,
-		Headers:   input.Headers,
-		Body:      input.Body,
+		ID:               s.Store.NewRequestID(),
+		Name:             strings.TrimSpace(input.Name),
+		FolderID:         input.FolderID,
+		ProjectID:        folder.ProjectID,
+		Rank:             rank,
+		Type:             input.Type,
+		URL:              input.URL,
+		QueryParams:      input.QueryParams,
+		Headers:          input.Headers,
+		BodyMode:         input.BodyMode,
+		Body:             input.Body,
+		BodyContentType:  input.BodyContentType,
+		FormParams:       input.FormParams,
+		MultipartParts:   input.MultipartParts,
+		BinaryBlobID:     input.BinaryBlobID,
+		GraphQL:          input.GraphQL,
+		Auth:             input.Auth,
+		Assertions:       input.Assertions,
+		Captures:         input.Captures,
+		PreRequestScript: input.PreRequestScript,
+		TestScript:       input.TestScript,
 	}
 
 	if err = s.Store.CreateRequest(ctx, authData.UserID, request); err != nil {
@@ -38,8 +38,15 @@ func (s *Service) DeleteFolder(ctx context.Context, input *DeleteFolderInput) (*
 		return nil, err
 	}
 
-	// delete folder
-	if err = s.Store.DeleteFolder(ctx, authData.UserID, folder); err != nil {
+	// delete the folder with its subfolders and requests
+	tree, err := s.getFolderTree(ctx, folder.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := tree.subtree(folder)
+
+	if err = s.Store.DeleteFolderTree(ctx, authData.UserID, folders, tree.subtreeRequests(folders)); err != nil {
 		return nil, errors.InternalServer{Msg: "Could not delete folder", Err: err}
 	}
 
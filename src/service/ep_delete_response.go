@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// DeleteResponseInput is the input of the endpoint
+type DeleteResponseInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// DeleteResponseOutput is the output of the endpoint
+type DeleteResponseOutput struct {
+	Response *store.Response `json:"response"`
+}
+
+// DeleteResponse implements the business logic for the endpoint
+func (s *Service) DeleteResponse(ctx context.Context, input *DeleteResponseInput) (*DeleteResponseOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get response
+	response, err := s.Store.GetResponseByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get response", Err: err}
+	} else if response == nil {
+		return nil, errors.NotFound{Obj: "Response"}
+	}
+
+	// check if the user has access to the project of the response
+	if err := s.checkAccessToProject(ctx, authData.UserID, response.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// delete response
+	if err = s.Store.DeleteResponse(ctx, authData.UserID, response); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not delete response", Err: err}
+	}
+
+	return &DeleteResponseOutput{
+		Response: response,
+	}, nil
+}
+
+// MakeDeleteResponseEndpoint creates the endpoint
+func MakeDeleteResponseEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*DeleteResponseInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.DeleteResponse(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
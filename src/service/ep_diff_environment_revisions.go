@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/diffutils"
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// DiffEnvironmentRevisionsInput is the input of the endpoint
+type DiffEnvironmentRevisionsInput struct {
+	FromRevisionID string `json:"from_revision_id" validate:"required"`
+	ToRevisionID   string `json:"to_revision_id" validate:"required"`
+}
+
+// DiffEnvironmentRevisionsOutput is the output of the endpoint
+type DiffEnvironmentRevisionsOutput struct {
+	Changes map[string]*diffutils.Change `json:"changes"`
+}
+
+// DiffEnvironmentRevisions implements the business logic for the endpoint
+func (s *Service) DiffEnvironmentRevisions(ctx context.Context, input *DiffEnvironmentRevisionsInput) (*DiffEnvironmentRevisionsOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get revisions
+	from, err := s.Store.GetRevisionByID(ctx, input.FromRevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if from == nil || from.EntityType != enums.EntityTypeEnvironment {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	to, err := s.Store.GetRevisionByID(ctx, input.ToRevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if to == nil || to.EntityType != enums.EntityTypeEnvironment {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	if from.EntityID != to.EntityID {
+		return nil, errors.BadRequest{Msg: "The revisions belong to different environments"}
+	}
+
+	// get environment, the access is checked against its current project because it could have been moved
+	environment, err := s.Store.GetEnvironmentByID(ctx, to.EntityID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+	} else if environment == nil {
+		return nil, errors.NotFound{Obj: "Environment"}
+	}
+
+	// check if the user has access to the project of the environment
+	if err := s.checkAccessToProject(ctx, authData.UserID, environment.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// compare revisions
+	changes, err := diffutils.Diff(from.Environment, to.Environment, revisionIgnoredFields...)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not compare revisions", Err: err}
+	}
+
+	return &DiffEnvironmentRevisionsOutput{
+		Changes: changes,
+	}, nil
+}
+
+// MakeDiffEnvironmentRevisionsEndpoint creates the endpoint
+func MakeDiffEnvironmentRevisionsEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*DiffEnvironmentRevisionsInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.DiffEnvironmentRevisions(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/diffutils"
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// DiffRequestRevisionsInput is the input of the endpoint
+type DiffRequestRevisionsInput struct {
+	FromRevisionID string `json:"from_revision_id" validate:"required"`
+	ToRevisionID   string `json:"to_revision_id" validate:"required"`
+}
+
+// DiffRequestRevisionsOutput is the output of the endpoint
+type DiffRequestRevisionsOutput struct {
+	Changes map[string]*diffutils.Change `json:"changes"`
+}
+
+// DiffRequestRevisions implements the business logic for the endpoint
+func (s *Service) DiffRequestRevisions(ctx context.Context, input *DiffRequestRevisionsInput) (*DiffRequestRevisionsOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get revisions
+	from, err := s.Store.GetRevisionByID(ctx, input.FromRevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if from == nil || from.EntityType != enums.EntityTypeRequest {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	to, err := s.Store.GetRevisionByID(ctx, input.ToRevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if to == nil || to.EntityType != enums.EntityTypeRequest {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	if from.EntityID != to.EntityID {
+		return nil, errors.BadRequest{Msg: "The revisions belong to different requests"}
+	}
+
+	// get request, the access is checked against its current project because it could have been moved
+	request, err := s.Store.GetRequestByID(ctx, to.EntityID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// compare revisions
+	changes, err := diffutils.Diff(from.Request, to.Request, revisionIgnoredFields...)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not compare revisions", Err: err}
+	}
+
+	return &DiffRequestRevisionsOutput{
+		Changes: changes,
+	}, nil
+}
+
+// MakeDiffRequestRevisionsEndpoint creates the endpoint
+func MakeDiffRequestRevisionsEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*DiffRequestRevisionsInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.DiffRequestRevisions(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// DuplicateFolderInput is the input of the endpoint
+type DuplicateFolderInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// DuplicateFolderOutput is the output of the endpoint
+type DuplicateFolderOutput struct {
+	Folder *store.Folder `json:"folder"`
+}
+
+// DuplicateFolder implements the business logic for the endpoint
+func (s *Service) DuplicateFolder(ctx context.Context, input *DuplicateFolderInput) (*DuplicateFolderOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the subfolders and requests of the folder
+	tree, err := s.getFolderTree(ctx, folder.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := tree.subtree(folder)
+	requests := tree.subtreeRequests(folders)
+
+	// duplicate the folder with its subfolders and requests, the copy is placed after the original
+	rank, err := s.rankFolder(ctx, authData.UserID, folder.ProjectID, folder.ParentFolderID, "", "", folder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cloneFolderTree(folders, requests, folder.ProjectID, folder.ParentFolderID)
+
+	folder.Name += " Copy"
+	folder.Rank = rank
+
+	if err = s.Store.CreateFolderTree(ctx, authData.UserID, folders, requests); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not duplicate folder", Err: err}
+	}
+
+	return &DuplicateFolderOutput{
+		Folder: folder,
+	}, nil
+}
+
+// MakeDuplicateFolderEndpoint creates the endpoint
+func MakeDuplicateFolderEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*DuplicateFolderInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.DuplicateFolder(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// DuplicateProjectInput is the input of the endpoint
+type DuplicateProjectInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// DuplicateProjectOutput is the output of the endpoint
+type DuplicateProjectOutput struct {
+	Project *store.Project `json:"project"`
+}
+
+// DuplicateProject implements the business logic for the endpoint
+func (s *Service) DuplicateProject(ctx context.Context, input *DuplicateProjectInput) (*DuplicateProjectOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	// get project
+	project, err := s.Store.GetProjectByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, errors.NotFound{Obj: "Project"}
+	}
+
+	// get the folders, requests and environments of the project
+	tree, err := s.getFolderTree(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	environments, err := s.Store.GetEnvironmentsByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environments", Err: err}
+	}
+
+	// duplicate project, the copy belongs to the user
+	project.ID = s.Store.NewProjectID()
+	project.Name += " Copy"
+	project.Updated = nil
+
+	projectUser := &store.ProjectUser{
+		ID:        s.Store.NewProjectUserID(project.ID, authData.UserID),
+		ProjectID: project.ID,
+		UserID:    authData.UserID,
+	}
+
+	// duplicate the folders with their subfolders and requests,
+	// the files of the body of the requests are copied to the project
+	folders := []*store.Folder{}
+	requests := []*store.Request{}
+
+	for _, folder := range tree.children[""] {
+		subtree := tree.subtree(folder)
+		subtreeRequests := tree.subtreeRequests(subtree)
+
+		s.cloneFolderTree(subtree, subtreeRequests, project.ID, "")
+
+		folders = append(folders, subtree...)
+		requests = append(requests, subtreeRequests...)
+	}
+
+	copied, err := s.copyRequestBlobs(ctx, authData.UserID, project.ID, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	// duplicate the environments
+	for _, environment := range environments {
+		environment.ID = s.Store.NewEnvironmentID()
+		environment.ProjectID = project.ID
+		environment.Updated = nil
+	}
+
+	if err = s.Store.CreateProjectTree(ctx, authData.UserID, project, projectUser, folders, requests, environments); err != nil {
+		s.deleteBlobs(ctx, copied)
+		return nil, errors.InternalServer{Msg: "Could not duplicate project", Err: err}
+	}
+
+	return &DuplicateProjectOutput{
+		Project: project,
+	}, nil
+}
+
+// MakeDuplicateProjectEndpoint creates the endpoint
+func MakeDuplicateProjectEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*DuplicateProjectInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.DuplicateProject(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
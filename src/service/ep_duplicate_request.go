@@ -38,8 +38,14 @@ func (s *Service) DuplicateRequest(ctx context.Context, input *DuplicateRequestI
 		return nil, err
 	}
 
-	// duplicate request
+	// duplicate request, the copy is placed after the original
+	rank, err := s.rankRequest(ctx, authData.UserID, request.FolderID, "", "", request.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	request.ID = s.Store.NewRequestID()
+	request.Rank = rank
 	request.Deleted = nil
 	request.Updated = nil
 	request.Name += " Copy"
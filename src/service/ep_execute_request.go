@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/executor"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/scripts"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ExecuteRequestInput is the input of the endpoint
+type ExecuteRequestInput struct {
+	ID            string `json:"id" validate:"required"`
+	EnvironmentID string `json:"environment_id" validate:"-"`
+}
+
+// ExecuteRequestOutput is the output of the endpoint, with the report of the assertions, captures and tests of the request.
+// The error describes why the request could not be sent or a script failed. The changes of the environment
+// made by the scripts and the captures are saved in the selected environment.
+type ExecuteRequestOutput struct {
+	Response           *executor.Response          `json:"response"`
+	Assertions         []*executor.AssertionResult `json:"assertions"`
+	Captures           []*executor.CaptureResult   `json:"captures"`
+	Tests              []*scripts.TestResult       `json:"tests"`
+	Logs               []string                    `json:"logs"`
+	EnvironmentChanges map[string]*string          `json:"environment_changes"`
+	Passed             bool                        `json:"passed"`
+	Error              string                      `json:"error,omitempty"`
+}
+
+// ExecuteRequest implements the business logic for the endpoint
+func (s *Service) ExecuteRequest(ctx context.Context, input *ExecuteRequestInput) (*ExecuteRequestOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the folders and the project with the defaults of the request
+	folders, project, err := s.getRequestParents(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// get environment (if included)
+	var environment *store.Environment
+
+	if input.EnvironmentID != "" {
+		environment, err = s.Store.GetEnvironmentByID(ctx, input.EnvironmentID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+		} else if environment == nil || environment.ProjectID != request.ProjectID {
+			return nil, errors.NotFound{Obj: "Environment"}
+		}
+	}
+
+	// run the scripts and send the request, the failures of the request are part of the output
+	result, err := s.Executor.Run(ctx, &executor.Execution{
+		Request:     request,
+		Folders:     folders,
+		Project:     project,
+		Environment: environment,
+		GetBlob:     s.getProjectBlob(ctx, request.ProjectID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// save the changes of the environment made by the scripts and the captures
+	if environment != nil && len(result.EnvironmentChanges) > 0 {
+		if _, err := s.saveEnvironmentChanges(ctx, authData.UserID, environment, result.EnvironmentChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ExecuteRequestOutput{
+		Response:           result.Response,
+		Assertions:         result.Assertions,
+		Captures:           result.Captures,
+		Tests:              result.Tests,
+		Logs:               result.Logs,
+		EnvironmentChanges: result.EnvironmentChanges,
+		Passed:             result.Passed,
+		Error:              result.Error,
+	}, nil
+}
+
+// MakeExecuteRequestEndpoint creates the endpoint
+func MakeExecuteRequestEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ExecuteRequestInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ExecuteRequest(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/exporters"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ExportHARInput is the input of the endpoint, the variables of the requests
+// are replaced with the values of the environment if it is set
+type ExportHARInput struct {
+	ID            string `json:"id" validate:"required"`
+	EnvironmentID string `json:"environment_id" validate:"-"`
+}
+
+// ExportHAROutput is the output of the endpoint, it contains a HAR 1.2 file.
+// The warnings describe the parts of the project that could not be exported.
+type ExportHAROutput struct {
+	HAR      *exporters.HARLog `json:"har"`
+	Warnings []string          `json:"warnings"`
+}
+
+// ExportHAR implements the business logic for the endpoint
+func (s *Service) ExportHAR(ctx context.Context, input *ExportHARInput) (*ExportHAROutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	// get the project with its folders, requests, environments and saved responses
+	project, err := s.getExportedProject(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the environment, it must belong to the project
+	var environment *store.Environment
+
+	if input.EnvironmentID != "" {
+		for _, candidate := range project.Environments {
+			if candidate.ID == input.EnvironmentID {
+				environment = candidate
+			}
+		}
+
+		if environment == nil {
+			return nil, errors.NotFound{Obj: "Environment"}
+		}
+	}
+
+	// convert the project
+	result := exporters.ExportHAR(project, environment)
+
+	return &ExportHAROutput{
+		HAR:      result.Log,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MakeExportHAREndpoint creates the endpoint
+func MakeExportHAREndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ExportHARInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ExportHAR(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
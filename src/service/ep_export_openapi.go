@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/exporters"
+	"apiboy/backend/src/httputils"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ExportOpenAPIInput is the input of the endpoint
+type ExportOpenAPIInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// ExportOpenAPIOutput is the output of the endpoint, it contains an OpenAPI 3.1 document.
+// The warnings describe the parts of the project that could not be exported.
+type ExportOpenAPIOutput struct {
+	Document *exporters.OpenAPIDocument `json:"document"`
+	Warnings []string                   `json:"warnings"`
+}
+
+// ExportOpenAPI implements the business logic for the endpoint
+func (s *Service) ExportOpenAPI(ctx context.Context, input *ExportOpenAPIInput) (*ExportOpenAPIOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	// get the project with its folders, requests, environments and saved responses
+	project, err := s.getExportedProject(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the project
+	result := exporters.ExportOpenAPI(project)
+
+	return &ExportOpenAPIOutput{
+		Document: result.Document,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MakeExportOpenAPIEndpoint creates the endpoint
+func MakeExportOpenAPIEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ExportOpenAPIInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ExportOpenAPI(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
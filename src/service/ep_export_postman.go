@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/exporters"
+	"apiboy/backend/src/httputils"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ExportPostmanInput is the input of the endpoint
+type ExportPostmanInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// ExportPostmanOutput is the output of the endpoint, it contains a Postman collection v2.1 and its environments.
+// The warnings describe the parts of the project that could not be exported.
+type ExportPostmanOutput struct {
+	Collection   *exporters.PostmanCollection    `json:"collection"`
+	Environments []*exporters.PostmanEnvironment `json:"environments"`
+	Warnings     []string                        `json:"warnings"`
+}
+
+// ExportPostman implements the business logic for the endpoint
+func (s *Service) ExportPostman(ctx context.Context, input *ExportPostmanInput) (*ExportPostmanOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	// get the project with its folders, requests and environments
+	project, err := s.getExportedProject(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the project
+	result := exporters.ExportPostman(project)
+
+	return &ExportPostmanOutput{
+		Collection:   result.Collection,
+		Environments: result.Environments,
+		Warnings:     result.Warnings,
+	}, nil
+}
+
+// MakeExportPostmanEndpoint creates the endpoint
+func MakeExportPostmanEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ExportPostmanInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ExportPostman(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
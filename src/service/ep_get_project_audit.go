@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// GetProjectAuditInput is the input of the endpoint
+type GetProjectAuditInput struct {
+	ProjectID string `json:"project_id" validate:"required"`
+	Cursor    string `json:"cursor" validate:"-"`
+	Limit     int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// GetProjectAuditOutput is the output of the endpoint
+type GetProjectAuditOutput struct {
+	Entries    []*store.AuditEntry `json:"entries"`
+	NextCursor string              `json:"next_cursor"`
+}
+
+// GetProjectAudit implements the business logic for the endpoint
+func (s *Service) GetProjectAudit(ctx context.Context, input *GetProjectAuditInput) (*GetProjectAuditOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	if input.Limit == 0 {
+		input.Limit = 20
+	}
+
+	// get project
+	project, err := s.Store.GetProjectByID(ctx, input.ProjectID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, errors.NotFound{Obj: "Project"}
+	}
+
+	// check if the user is the owner of the project
+	if project.Created.By != authData.UserID {
+		return nil, errors.Unauthorized{Msg: "The user is not the owner of the project"}
+	}
+
+	// get audit entries of the project
+	filter := &store.AuditFilter{
+		ProjectID: project.ID,
+	}
+
+	entries, nextCursor, err := s.Store.GetAuditEntries(ctx, filter, input.Cursor, input.Limit)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get audit entries", Err: err}
+	}
+
+	return &GetProjectAuditOutput{
+		Entries:    entries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// MakeGetProjectAuditEndpoint creates the endpoint
+func MakeGetProjectAuditEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*GetProjectAuditInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.GetProjectAudit(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// GetProjectTreeInput is the input of the endpoint
+type GetProjectTreeInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// GetProjectTreeOutput is the output of the endpoint
+type GetProjectTreeOutput struct {
+	Project *store.Project `json:"project"`
+	Folders []*FolderNode  `json:"folders"`
+}
+
+// GetProjectTree implements the business logic for the endpoint
+func (s *Service) GetProjectTree(ctx context.Context, input *GetProjectTreeInput) (*GetProjectTreeOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ID); err != nil {
+		return nil, err
+	}
+
+	// get project
+	project, err := s.Store.GetProjectByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, errors.NotFound{Obj: "Project"}
+	}
+
+	// get the folders and requests of the project
+	tree, err := s.getFolderTree(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetProjectTreeOutput{
+		Project: project,
+		Folders: tree.nodes("", map[string]bool{}),
+	}, nil
+}
+
+// MakeGetProjectTreeEndpoint creates the endpoint
+func MakeGetProjectTreeEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*GetProjectTreeInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.GetProjectTree(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
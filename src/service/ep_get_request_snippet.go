@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/snippets"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// GetRequestSnippetInput is the input of the endpoint
+type GetRequestSnippetInput struct {
+	ID            string `json:"id" validate:"required"`
+	EnvironmentID string `json:"environment_id" validate:"-"`
+	Language      string `json:"language" validate:"required,snippet_language"`
+}
+
+// GetRequestSnippetOutput is the output of the endpoint
+type GetRequestSnippetOutput struct {
+	Snippet  string   `json:"snippet"`
+	Warnings []string `json:"warnings"`
+}
+
+// GetRequestSnippet implements the business logic for the endpoint
+func (s *Service) GetRequestSnippet(ctx context.Context, input *GetRequestSnippetInput) (*GetRequestSnippetOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the folders and the project with the defaults of the request
+	folders, project, err := s.getRequestParents(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// get environment (if included)
+	var environment *store.Environment
+
+	if input.EnvironmentID != "" {
+		environment, err = s.Store.GetEnvironmentByID(ctx, input.EnvironmentID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+		} else if environment == nil || environment.ProjectID != request.ProjectID {
+			return nil, errors.NotFound{Obj: "Environment"}
+		}
+	}
+
+	// the files of the body are only referenced by their names
+	resolved := requestutils.Resolve(request, folders, project, environment)
+
+	snippetRequest, err := snippets.NewRequest(resolved, request, s.getProjectBlob(ctx, request.ProjectID))
+	if err != nil {
+		if _, ok := err.(errors.InternalServer); ok {
+			return nil, err
+		}
+
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	snippet := snippets.Generate(input.Language, snippetRequest)
+
+	return &GetRequestSnippetOutput{
+		Snippet:  snippet.Code,
+		Warnings: snippet.Warnings,
+	}, nil
+}
+
+// MakeGetRequestSnippetEndpoint creates the endpoint
+func MakeGetRequestSnippetEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*GetRequestSnippetInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.GetRequestSnippet(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
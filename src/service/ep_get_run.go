@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// GetRunInput is the input of the endpoint
+type GetRunInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// GetRunOutput is the output of the endpoint, it contains the progress of the run and the results of its requests
+type GetRunOutput struct {
+	Run *store.Run `json:"run"`
+}
+
+// GetRun implements the business logic for the endpoint
+func (s *Service) GetRun(ctx context.Context, input *GetRunInput) (*GetRunOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get run
+	run, err := s.Store.GetRunByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get run", Err: err}
+	} else if run == nil {
+		return nil, errors.NotFound{Obj: "Run"}
+	}
+
+	// check if the user has access to the project of the run
+	if err := s.checkAccessToProject(ctx, authData.UserID, run.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// finish the run if the instance executing it stopped
+	run, err = s.finishStaleRun(ctx, run)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not finish run", Err: err}
+	}
+
+	// get the results of the run
+	results, err := s.Store.GetRunResults(ctx, run.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get run results", Err: err}
+	}
+
+	run.SetResults(results)
+
+	return &GetRunOutput{
+		Run: run,
+	}, nil
+}
+
+// MakeGetRunEndpoint creates the endpoint
+func MakeGetRunEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*GetRunInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.GetRun(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
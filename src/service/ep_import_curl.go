@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ImportCurlInput is the input of the endpoint
+type ImportCurlInput struct {
+	FolderID string `json:"folder_id" validate:"required"`
+	Command  string `json:"command" validate:"required"`
+	Name     string `json:"name" validate:"-"`
+}
+
+// ImportCurlOutput is the output of the endpoint
+type ImportCurlOutput struct {
+	Request  *store.Request `json:"request"`
+	Warnings []string       `json:"warnings"`
+}
+
+// ImportCurl implements the business logic for the endpoint
+func (s *Service) ImportCurl(ctx context.Context, input *ImportCurlInput) (*ImportCurlOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.FolderID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// parse the command
+	request, warnings, err := importers.ParseCurl(input.Command)
+	if err != nil {
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	// the request is placed at the end of the folder
+	rank, err := s.rankRequest(ctx, authData.UserID, folder.ID, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	request.ID = s.Store.NewRequestID()
+	request.FolderID = folder.ID
+	request.ProjectID = folder.ProjectID
+	request.Rank = rank
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		request.Name = name
+	}
+
+	// create request
+	if err = s.Store.CreateRequest(ctx, authData.UserID, request); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not create request", Err: err}
+	}
+
+	return &ImportCurlOutput{
+		Request:  request,
+		Warnings: warnings,
+	}, nil
+}
+
+// MakeImportCurlEndpoint creates the endpoint
+func MakeImportCurlEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ImportCurlInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ImportCurl(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
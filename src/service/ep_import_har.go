@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ImportHARInput is the input of the endpoint, it contains a HAR 1.2 file
+type ImportHARInput struct {
+	HAR json.RawMessage `json:"har" validate:"required"`
+}
+
+// ImportHAROutput is the output of the endpoint, the warnings describe
+// the parts of the file that could not be imported
+type ImportHAROutput struct {
+	Project  *store.Project `json:"project"`
+	Warnings []string       `json:"warnings"`
+}
+
+// ImportHAR implements the business logic for the endpoint
+func (s *Service) ImportHAR(ctx context.Context, input *ImportHARInput) (*ImportHAROutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// convert the file
+	result, err := importers.New(s.Store, s.Config.MaxFolderDepth).ImportHAR(input.HAR)
+	if err != nil {
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	// create the project
+	if err := s.createImportedProject(ctx, authData.UserID, result); err != nil {
+		return nil, err
+	}
+
+	return &ImportHAROutput{
+		Project:  result.Project,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MakeImportHAREndpoint creates the endpoint
+func MakeImportHAREndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ImportHARInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ImportHAR(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
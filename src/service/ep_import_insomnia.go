@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/logger"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ImportInsomniaInput is the input of the endpoint, it contains an Insomnia v4 export in JSON or YAML
+type ImportInsomniaInput struct {
+	Export string `json:"export" validate:"required"`
+}
+
+// ImportInsomniaOutput is the output of the endpoint, with a project for each workspace of the export.
+// The warnings describe the parts of the export that could not be imported.
+type ImportInsomniaOutput struct {
+	Projects []*store.Project `json:"projects"`
+	Warnings []string         `json:"warnings"`
+}
+
+// ImportInsomnia implements the business logic for the endpoint
+func (s *Service) ImportInsomnia(ctx context.Context, input *ImportInsomniaInput) (*ImportInsomniaOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// convert the export
+	results, err := importers.New(s.Store, s.Config.MaxFolderDepth).ImportInsomnia([]byte(input.Export))
+	if err != nil {
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	output := &ImportInsomniaOutput{
+		Projects: []*store.Project{},
+		Warnings: []string{},
+	}
+
+	for _, result := range results {
+		// create the project
+		if err := s.createImportedProject(ctx, authData.UserID, result); err != nil {
+			return nil, err
+		}
+
+		// the audit middleware records a single entity, so each project is recorded here
+		if err := s.recordAuditEntry(ctx, enums.AuditActionImportProject, enums.EntityTypeProject, nil, result.Project); err != nil {
+			s.Logger.Error("Could not create audit entry",
+				logger.Field{Key: "action", Val: enums.AuditActionImportProject},
+				logger.Field{Key: "err", Val: err},
+			)
+		}
+
+		output.Projects = append(output.Projects, result.Project)
+
+		// the warnings of the workspaces are told apart by the names of their projects
+		for _, warning := range result.Warnings {
+			if len(results) > 1 {
+				warning = fmt.Sprintf("Workspace %q: %s", result.Project.Name, warning)
+			}
+
+			output.Warnings = append(output.Warnings, warning)
+		}
+	}
+
+	return output, nil
+}
+
+// MakeImportInsomniaEndpoint creates the endpoint
+func MakeImportInsomniaEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ImportInsomniaInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ImportInsomnia(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
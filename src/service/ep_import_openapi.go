@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ImportOpenAPIInput is the input of the endpoint, it contains an OpenAPI 3.x or Swagger 2.0 document in JSON or YAML
+type ImportOpenAPIInput struct {
+	Document string `json:"document" validate:"required"`
+}
+
+// ImportOpenAPIOutput is the output of the endpoint, the warnings describe
+// the parts of the document that could not be imported
+type ImportOpenAPIOutput struct {
+	Project  *store.Project `json:"project"`
+	Warnings []string       `json:"warnings"`
+}
+
+// ImportOpenAPI implements the business logic for the endpoint
+func (s *Service) ImportOpenAPI(ctx context.Context, input *ImportOpenAPIInput) (*ImportOpenAPIOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// convert the document
+	result, err := importers.New(s.Store, s.Config.MaxFolderDepth).ImportOpenAPI([]byte(input.Document))
+	if err != nil {
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	// create the project
+	if err := s.createImportedProject(ctx, authData.UserID, result); err != nil {
+		return nil, err
+	}
+
+	return &ImportOpenAPIOutput{
+		Project:  result.Project,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MakeImportOpenAPIEndpoint creates the endpoint
+func MakeImportOpenAPIEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ImportOpenAPIInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ImportOpenAPI(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
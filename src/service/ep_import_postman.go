@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ImportPostmanInput is the input of the endpoint, it contains a Postman collection v2.1 and its environments
+type ImportPostmanInput struct {
+	Collection   json.RawMessage   `json:"collection" validate:"required"`
+	Environments []json.RawMessage `json:"environments" validate:"-"`
+}
+
+// ImportPostmanOutput is the output of the endpoint, the warnings describe
+// the parts of the collection that could not be imported
+type ImportPostmanOutput struct {
+	Project  *store.Project `json:"project"`
+	Warnings []string       `json:"warnings"`
+}
+
+// ImportPostman implements the business logic for the endpoint
+func (s *Service) ImportPostman(ctx context.Context, input *ImportPostmanInput) (*ImportPostmanOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// convert the collection
+	environments := [][]byte{}
+	for _, environment := range input.Environments {
+		environments = append(environments, environment)
+	}
+
+	result, err := importers.New(s.Store, s.Config.MaxFolderDepth).ImportPostmanCollection(input.Collection, environments)
+	if err != nil {
+		return nil, errors.BadRequest{Msg: err.Error()}
+	}
+
+	// create the project
+	if err := s.createImportedProject(ctx, authData.UserID, result); err != nil {
+		return nil, err
+	}
+
+	return &ImportPostmanOutput{
+		Project:  result.Project,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MakeImportPostmanEndpoint creates the endpoint
+func MakeImportPostmanEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ImportPostmanInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ImportPostman(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
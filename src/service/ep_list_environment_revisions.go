@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ListEnvironmentRevisionsInput is the input of the endpoint
+type ListEnvironmentRevisionsInput struct {
+	EnvironmentID string `json:"environment_id" validate:"required"`
+	Cursor        string `json:"cursor" validate:"-"`
+	Limit         int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListEnvironmentRevisionsOutput is the output of the endpoint
+type ListEnvironmentRevisionsOutput struct {
+	Revisions  []*store.Revision `json:"revisions"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+// ListEnvironmentRevisions implements the business logic for the endpoint
+func (s *Service) ListEnvironmentRevisions(ctx context.Context, input *ListEnvironmentRevisionsInput) (*ListEnvironmentRevisionsOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	if input.Limit == 0 {
+		input.Limit = 20
+	}
+
+	// get environment
+	environment, err := s.Store.GetEnvironmentByID(ctx, input.EnvironmentID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+	} else if environment == nil {
+		return nil, errors.NotFound{Obj: "Environment"}
+	}
+
+	// check if the user has access to the project of the environment
+	if err := s.checkAccessToProject(ctx, authData.UserID, environment.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get revisions
+	revisions, nextCursor, err := s.Store.GetRevisionsByEntityID(ctx, environment.ID, input.Cursor, input.Limit)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revisions", Err: err}
+	}
+
+	return &ListEnvironmentRevisionsOutput{
+		Revisions:  revisions,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// MakeListEnvironmentRevisionsEndpoint creates the endpoint
+func MakeListEnvironmentRevisionsEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ListEnvironmentRevisionsInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ListEnvironmentRevisions(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ListRequestRevisionsInput is the input of the endpoint
+type ListRequestRevisionsInput struct {
+	RequestID string `json:"request_id" validate:"required"`
+	Cursor    string `json:"cursor" validate:"-"`
+	Limit     int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListRequestRevisionsOutput is the output of the endpoint
+type ListRequestRevisionsOutput struct {
+	Revisions  []*store.Revision `json:"revisions"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+// ListRequestRevisions implements the business logic for the endpoint
+func (s *Service) ListRequestRevisions(ctx context.Context, input *ListRequestRevisionsInput) (*ListRequestRevisionsOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	if input.Limit == 0 {
+		input.Limit = 20
+	}
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.RequestID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get revisions
+	revisions, nextCursor, err := s.Store.GetRevisionsByEntityID(ctx, request.ID, input.Cursor, input.Limit)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revisions", Err: err}
+	}
+
+	return &ListRequestRevisionsOutput{
+		Revisions:  revisions,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// MakeListRequestRevisionsEndpoint creates the endpoint
+func MakeListRequestRevisionsEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ListRequestRevisionsInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ListRequestRevisions(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
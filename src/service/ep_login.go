@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"strings"
 
 	"apiboy/backend/src/authutils"
@@ -37,15 +38,25 @@ func (s *Service) Login(ctx context.Context, input *LoginInput) (*LoginOutput, e
 		return nil, errors.Unauthorized{Msg: "Invalid user"}
 	}
 
-	// check user password
-	if err = authutils.CheckPassword(user.Password, password); err != nil {
+	if user.Disabled {
+		return nil, errors.Unauthorized{Msg: "Disabled user"}
+	}
+
+	// check user password, the users that must reset their password can only log in
+	// with their temp code, and the token can only be used to change the password
+	if user.PasswordResetRequired {
+		if user.TempCode == "" || subtle.ConstantTimeCompare([]byte(user.TempCode), []byte(password)) != 1 {
+			return nil, errors.Unauthorized{Msg: "The password must be reset"}
+		}
+	} else if err = authutils.CheckPassword(user.Password, password); err != nil {
 		return nil, errors.Unauthorized{Msg: "Invalid password", Err: err}
 	}
 
 	// create token for the user
 	token := &store.Token{
-		ID:     s.Store.NewTokenID(),
-		UserID: user.ID,
+		ID:            s.Store.NewTokenID(),
+		UserID:        user.ID,
+		PasswordReset: user.PasswordResetRequired,
 	}
 
 	if err = s.Store.CreateToken(ctx, token); err != nil {
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// MoveEnvironmentInput is the input of the endpoint
+type MoveEnvironmentInput struct {
+	ID        string `json:"id" validate:"required"`
+	ProjectID string `json:"project_id" validate:"required"`
+}
+
+// MoveEnvironmentOutput is the output of the endpoint
+type MoveEnvironmentOutput struct {
+	Environment *store.Environment `json:"environment"`
+}
+
+// MoveEnvironment implements the business logic for the endpoint
+func (s *Service) MoveEnvironment(ctx context.Context, input *MoveEnvironmentInput) (*MoveEnvironmentOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get environment
+	environment, err := s.Store.GetEnvironmentByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+	} else if environment == nil {
+		return nil, errors.NotFound{Obj: "Environment"}
+	}
+
+	// check if the user has access to the project of the environment and to the target project
+	if err := s.checkAccessToProject(ctx, authData.UserID, environment.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if environment.ProjectID == input.ProjectID {
+		return &MoveEnvironmentOutput{
+			Environment: environment,
+		}, nil
+	}
+
+	// move environment
+	environment, err = s.Store.PatchEnvironment(ctx, authData.UserID, environment.ID, 0, func(environment *store.Environment) {
+		environment.ProjectID = input.ProjectID
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not move environment", Err: err}
+	}
+
+	return &MoveEnvironmentOutput{
+		Environment: environment,
+	}, nil
+}
+
+// MakeMoveEnvironmentEndpoint creates the endpoint
+func MakeMoveEnvironmentEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*MoveEnvironmentInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.MoveEnvironment(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
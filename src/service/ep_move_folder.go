@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// MoveFolderInput is the input of the endpoint, the folder is moved with its subfolders and requests
+// to a parent folder of a project, which can be another project. An empty parent folder is the root of the project.
+type MoveFolderInput struct {
+	ID             string `json:"id" validate:"required"`
+	ProjectID      string `json:"project_id" validate:"required"`
+	ParentFolderID string `json:"parent_folder_id" validate:"-"`
+}
+
+// MoveFolderOutput is the output of the endpoint
+type MoveFolderOutput struct {
+	Folder *store.Folder `json:"folder"`
+}
+
+// MoveFolder implements the business logic for the endpoint
+func (s *Service) MoveFolder(ctx context.Context, input *MoveFolderInput) (*MoveFolderOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder and to the target project
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if folder.ProjectID == input.ProjectID && folder.ParentFolderID == input.ParentFolderID {
+		return &MoveFolderOutput{
+			Folder: folder,
+		}, nil
+	}
+
+	// check if the folder with its subfolders fits in the parent folder
+	var tree *folderTree
+	levels := 0
+
+	if folder.ProjectID == input.ProjectID {
+		if levels, err = s.checkFolderMove(ctx, folder, input.ParentFolderID); err != nil {
+			return nil, err
+		}
+	} else {
+		if tree, err = s.getFolderTree(ctx, folder.ProjectID); err != nil {
+			return nil, err
+		}
+
+		if _, err := s.getParentChain(ctx, input.ProjectID, input.ParentFolderID, tree.height(folder)); err != nil {
+			return nil, err
+		}
+	}
+
+	// the folder is placed at the end of the parent folder
+	rank, err := s.rankFolder(ctx, authData.UserID, input.ProjectID, input.ParentFolderID, folder.ID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// move the folder in the same project, the parent folders are checked again when it is written
+	if folder.ProjectID == input.ProjectID {
+		folder, err = s.Store.MoveFolder(ctx, authData.UserID, folder.ID, 0, input.ParentFolderID, levels, s.Config.MaxFolderDepth, func(folder *store.Folder) {
+			folder.Rank = rank
+		})
+		if err != nil {
+			switch err.(type) {
+			case errors.BadRequest, errors.Conflict, errors.NotFound:
+				return nil, err
+			}
+
+			return nil, errors.InternalServer{Msg: "Could not move folder", Err: err}
+		}
+
+		return &MoveFolderOutput{
+			Folder: folder,
+		}, nil
+	}
+
+	// move the folder with its subfolders and requests to the other project,
+	// the files of the body of the requests are copied to the project
+	folders := tree.subtree(folder)
+	requests := tree.subtreeRequests(folders)
+
+	copied, err := s.copyRequestBlobs(ctx, authData.UserID, input.ProjectID, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range folders {
+		f.ProjectID = input.ProjectID
+	}
+
+	for _, r := range requests {
+		r.ProjectID = input.ProjectID
+	}
+
+	folder.ParentFolderID = input.ParentFolderID
+	folder.Rank = rank
+
+	// the documents are only written if they were not modified after they were read,
+	// and the copies of the files are deleted if the move fails
+	if err = s.Store.MoveFolderTree(ctx, authData.UserID, folders, requests); err != nil {
+		s.deleteBlobs(ctx, copied)
+
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not move folder", Err: err}
+	}
+
+	return &MoveFolderOutput{
+		Folder: folder,
+	}, nil
+}
+
+// MakeMoveFolderEndpoint creates the endpoint
+func MakeMoveFolderEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*MoveFolderInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.MoveFolder(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// MoveRequestInput is the input of the endpoint, the folder can be a folder of another project
+type MoveRequestInput struct {
+	ID       string `json:"id" validate:"required"`
+	FolderID string `json:"folder_id" validate:"required"`
+}
+
+// MoveRequestOutput is the output of the endpoint
+type MoveRequestOutput struct {
+	Request *store.Request `json:"request"`
+}
+
+// MoveRequest implements the business logic for the endpoint
+func (s *Service) MoveRequest(ctx context.Context, input *MoveRequestInput) (*MoveRequestOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.FolderID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if folder.ID == request.FolderID {
+		return &MoveRequestOutput{
+			Request: request,
+		}, nil
+	}
+
+	// the request is placed at the end of the folder
+	rank, err := s.rankRequest(ctx, authData.UserID, folder.ID, request.ID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	// the files of the body are copied to the project of the folder
+	copied, err := s.copyRequestBlobs(ctx, authData.UserID, folder.ProjectID, []*store.Request{request})
+	if err != nil {
+		return nil, err
+	}
+
+	// move request
+	request, err = s.Store.PatchRequest(ctx, authData.UserID, request.ID, 0, func(r *store.Request) {
+		r.FolderID = folder.ID
+		r.ProjectID = folder.ProjectID
+		r.Rank = rank
+		r.MultipartParts = request.MultipartParts
+		r.BinaryBlobID = request.BinaryBlobID
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, copied)
+
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not move request", Err: err}
+	}
+
+	return &MoveRequestOutput{
+		Request: request,
+	}, nil
+}
+
+// MakeMoveRequestEndpoint creates the endpoint
+func MakeMoveRequestEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*MoveRequestInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.MoveRequest(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
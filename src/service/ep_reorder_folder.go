@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ReorderFolderInput is the input of the endpoint, the folder is placed before or after a sibling
+// (in the parent folder of the sibling), or at the end of the parent folder if no sibling is included.
+// An empty parent folder is the root of the project.
+type ReorderFolderInput struct {
+	ID             string  `json:"id" validate:"required"`
+	BeforeID       string  `json:"before_id" validate:"-"`
+	AfterID        string  `json:"after_id" validate:"-"`
+	ParentFolderID *string `json:"parent_folder_id" validate:"-"`
+}
+
+// ReorderFolderOutput is the output of the endpoint
+type ReorderFolderOutput struct {
+	Folder *store.Folder `json:"folder"`
+}
+
+// ReorderFolder implements the business logic for the endpoint
+func (s *Service) ReorderFolder(ctx context.Context, input *ReorderFolderInput) (*ReorderFolderOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get folder
+	folder, err := s.Store.GetFolderByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the parent folder where the folder is placed
+	parentFolderID := folder.ParentFolderID
+
+	if siblingID := input.BeforeID + input.AfterID; siblingID != "" {
+		sibling, err := s.Store.GetFolderByID(ctx, siblingID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get sibling folder", Err: err}
+		} else if sibling == nil || sibling.ProjectID != folder.ProjectID {
+			return nil, errors.NotFound{Obj: "Sibling folder"}
+		}
+
+		parentFolderID = sibling.ParentFolderID
+	} else if input.ParentFolderID != nil {
+		parentFolderID = *input.ParentFolderID
+	}
+
+	if input.ParentFolderID != nil && *input.ParentFolderID != parentFolderID {
+		return nil, errors.BadRequest{Msg: "Invalid parent folder for sibling"}
+	}
+
+	// check if the folder can be moved to the parent folder (if changed)
+	moved := parentFolderID != folder.ParentFolderID
+	levels := 0
+
+	if moved {
+		if levels, err = s.checkFolderMove(ctx, folder, parentFolderID); err != nil {
+			return nil, err
+		}
+	}
+
+	// get the rank of the new position
+	rank, err := s.rankFolder(ctx, authData.UserID, folder.ProjectID, parentFolderID, folder.ID, input.BeforeID, input.AfterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// update only the position, so the changes done at the same time by other users are kept,
+	// and the parent folders are checked again when a moved folder is written
+	patch := func(folder *store.Folder) {
+		folder.Rank = rank
+	}
+
+	if moved {
+		folder, err = s.Store.MoveFolder(ctx, authData.UserID, folder.ID, 0, parentFolderID, levels, s.Config.MaxFolderDepth, patch)
+	} else {
+		folder, err = s.Store.PatchFolder(ctx, authData.UserID, folder.ID, 0, patch)
+	}
+	if err != nil {
+		switch err.(type) {
+		case errors.BadRequest, errors.Conflict, errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not reorder folder", Err: err}
+	}
+
+	return &ReorderFolderOutput{
+		Folder: folder,
+	}, nil
+}
+
+// MakeReorderFolderEndpoint creates the endpoint
+func MakeReorderFolderEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ReorderFolderInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ReorderFolder(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ReorderRequestInput is the input of the endpoint, the request is placed before or after a sibling
+// (in the folder of the sibling), or at the end of the folder if no sibling is included
+type ReorderRequestInput struct {
+	ID       string `json:"id" validate:"required"`
+	BeforeID string `json:"before_id" validate:"-"`
+	AfterID  string `json:"after_id" validate:"-"`
+	FolderID string `json:"folder_id" validate:"-"`
+}
+
+// ReorderRequestOutput is the output of the endpoint
+type ReorderRequestOutput struct {
+	Request *store.Request `json:"request"`
+}
+
+// ReorderRequest implements the business logic for the endpoint
+func (s *Service) ReorderRequest(ctx context.Context, input *ReorderRequestInput) (*ReorderRequestOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the folder where the request is placed
+	folderID := request.FolderID
+
+	if siblingID := input.BeforeID + input.AfterID; siblingID != "" {
+		sibling, err := s.Store.GetRequestByID(ctx, siblingID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get sibling request", Err: err}
+		} else if sibling == nil || sibling.ProjectID != request.ProjectID {
+			return nil, errors.NotFound{Obj: "Sibling request"}
+		}
+
+		folderID = sibling.FolderID
+	} else if input.FolderID != "" {
+		folderID = input.FolderID
+	}
+
+	if input.FolderID != "" && input.FolderID != folderID {
+		return nil, errors.BadRequest{Msg: "Invalid folder for sibling"}
+	}
+
+	if folderID != request.FolderID {
+		folder, err := s.Store.GetFolderByID(ctx, folderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+		} else if folder == nil {
+			return nil, errors.NotFound{Obj: "Folder"}
+		}
+
+		if request.ProjectID != folder.ProjectID {
+			return nil, errors.BadRequest{Msg: "Invalid folder for project"}
+		}
+	}
+
+	// get the rank of the new position
+	rank, err := s.rankRequest(ctx, authData.UserID, folderID, request.ID, input.BeforeID, input.AfterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// update only the position, so the changes done at the same time by other users are kept
+	request, err = s.Store.PatchRequest(ctx, authData.UserID, request.ID, 0, func(request *store.Request) {
+		request.FolderID = folderID
+		request.Rank = rank
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not reorder request", Err: err}
+	}
+
+	return &ReorderRequestOutput{
+		Request: request,
+	}, nil
+}
+
+// MakeReorderRequestEndpoint creates the endpoint
+func MakeReorderRequestEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ReorderRequestInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ReorderRequest(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
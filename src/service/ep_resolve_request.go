@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// ResolveRequestInput is the input of the endpoint
+type ResolveRequestInput struct {
+	ID            string `json:"id" validate:"required"`
+	EnvironmentID string `json:"environment_id" validate:"-"`
+}
+
+// ResolveRequestOutput is the output of the endpoint
+type ResolveRequestOutput struct {
+	Resolved *requestutils.ResolvedRequest `json:"resolved"`
+}
+
+// ResolveRequest implements the business logic for the endpoint
+func (s *Service) ResolveRequest(ctx context.Context, input *ResolveRequestInput) (*ResolveRequestOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the folders and the project with the defaults of the request
+	folders, project, err := s.getRequestParents(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// get environment (if included)
+	var environment *store.Environment
+
+	if input.EnvironmentID != "" {
+		environment, err = s.Store.GetEnvironmentByID(ctx, input.EnvironmentID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+		} else if environment == nil || environment.ProjectID != request.ProjectID {
+			return nil, errors.NotFound{Obj: "Environment"}
+		}
+	}
+
+	return &ResolveRequestOutput{
+		Resolved: requestutils.Resolve(request, folders, project, environment),
+	}, nil
+}
+
+// MakeResolveRequestEndpoint creates the endpoint
+func MakeResolveRequestEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*ResolveRequestInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.ResolveRequest(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
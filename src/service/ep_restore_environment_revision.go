@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// RestoreEnvironmentRevisionInput is the input of the endpoint
+type RestoreEnvironmentRevisionInput struct {
+	ID         string `json:"id" validate:"required"`
+	RevisionID string `json:"revision_id" validate:"required"`
+}
+
+// RestoreEnvironmentRevisionOutput is the output of the endpoint
+type RestoreEnvironmentRevisionOutput struct {
+	Environment *store.Environment `json:"environment"`
+}
+
+// RestoreEnvironmentRevision implements the business logic for the endpoint
+func (s *Service) RestoreEnvironmentRevision(ctx context.Context, input *RestoreEnvironmentRevisionInput) (*RestoreEnvironmentRevisionOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get environment
+	environment, err := s.Store.GetEnvironmentByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+	} else if environment == nil {
+		return nil, errors.NotFound{Obj: "Environment"}
+	}
+
+	// check if the user has access to the project of the environment
+	if err := s.checkAccessToProject(ctx, authData.UserID, environment.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get revision
+	revision, err := s.Store.GetRevisionByID(ctx, input.RevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if revision == nil || revision.EntityType != enums.EntityTypeEnvironment || revision.EntityID != environment.ID {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	// restore the snapshot, keeping the identity and history of the current environment
+	restored := *revision.Environment
+	restored.ID = environment.ID
+	restored.ProjectID = environment.ProjectID
+	restored.Version = environment.Version
+	restored.Created = environment.Created
+	restored.Deleted = nil
+
+	if err = s.Store.UpdateEnvironment(ctx, authData.UserID, &restored); err != nil {
+		if _, ok := err.(errors.Conflict); ok {
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not restore environment", Err: err}
+	}
+
+	return &RestoreEnvironmentRevisionOutput{
+		Environment: &restored,
+	}, nil
+}
+
+// MakeRestoreEnvironmentRevisionEndpoint creates the endpoint
+func MakeRestoreEnvironmentRevisionEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*RestoreEnvironmentRevisionInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.RestoreEnvironmentRevision(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// RestoreFolderInput is the input of the endpoint
+type RestoreFolderInput struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// RestoreFolderOutput is the output of the endpoint
+type RestoreFolderOutput struct {
+	Folder *store.Folder `json:"folder"`
+}
+
+// RestoreFolder implements the business logic for the endpoint
+func (s *Service) RestoreFolder(ctx context.Context, input *RestoreFolderInput) (*RestoreFolderOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get deleted folder
+	folder, err := s.Store.GetDeletedFolderByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	// check if the user has access to the project of the folder
+	if err := s.checkAccessToProject(ctx, authData.UserID, folder.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get the subfolders and requests deleted with the folder
+	deletedFolders, err := s.Store.GetFoldersByProjectID(ctx, folder.ProjectID, true)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folders", Err: err}
+	}
+
+	deletedRequests, err := s.Store.GetRequestsByProjectID(ctx, folder.ProjectID, true)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get requests", Err: err}
+	}
+
+	tree := newFolderTree(deletedFolders, deletedRequests)
+
+	folders := []*store.Folder{}
+	for _, f := range tree.subtree(folder) {
+		if f.Deleted.At.Equal(folder.Deleted.At) {
+			folders = append(folders, f)
+		}
+	}
+
+	requests := []*store.Request{}
+	for _, r := range tree.subtreeRequests(folders) {
+		if r.Deleted.At.Equal(folder.Deleted.At) {
+			requests = append(requests, r)
+		}
+	}
+
+	// the folder is restored in the root of the project if its parent folder does not exist anymore
+	if folder.ParentFolderID != "" {
+		parent, err := s.Store.GetFolderByID(ctx, folder.ParentFolderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get parent folder", Err: err}
+		} else if parent == nil {
+			folder.ParentFolderID = ""
+		}
+	}
+
+	// check if the restored subfolders exceed the maximum depth
+	if _, err := s.getParentChain(ctx, folder.ProjectID, folder.ParentFolderID, tree.height(folder)); err != nil {
+		return nil, err
+	}
+
+	// restore the folder with its subfolders and requests
+	if err = s.Store.RestoreFolderTree(ctx, authData.UserID, folders, requests); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not restore folder", Err: err}
+	}
+
+	return &RestoreFolderOutput{
+		Folder: folder,
+	}, nil
+}
+
+// MakeRestoreFolderEndpoint creates the endpoint
+func MakeRestoreFolderEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*RestoreFolderInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.RestoreFolder(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
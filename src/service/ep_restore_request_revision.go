@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// RestoreRequestRevisionInput is the input of the endpoint
+type RestoreRequestRevisionInput struct {
+	ID         string `json:"id" validate:"required"`
+	RevisionID string `json:"revision_id" validate:"required"`
+}
+
+// RestoreRequestRevisionOutput is the output of the endpoint
+type RestoreRequestRevisionOutput struct {
+	Request *store.Request `json:"request"`
+}
+
+// RestoreRequestRevision implements the business logic for the endpoint
+func (s *Service) RestoreRequestRevision(ctx context.Context, input *RestoreRequestRevisionInput) (*RestoreRequestRevisionOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.ID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get revision
+	revision, err := s.Store.GetRevisionByID(ctx, input.RevisionID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get revision", Err: err}
+	} else if revision == nil || revision.EntityType != enums.EntityTypeRequest || revision.EntityID != request.ID {
+		return nil, errors.NotFound{Obj: "Revision"}
+	}
+
+	// restore the snapshot, keeping the identity and history of the current request
+	restored := *revision.Request
+	restored.ID = request.ID
+	restored.ProjectID = request.ProjectID
+	restored.Version = request.Version
+	restored.Created = request.Created
+	restored.Deleted = nil
+
+	// the secrets of the auth are not saved in the revisions, the current ones are kept
+	restored.Auth.RestoreSecrets(request.Auth)
+
+	// keep the current folder if the one in the snapshot is not valid anymore
+	if restored.FolderID != request.FolderID {
+		folder, err := s.Store.GetFolderByID(ctx, restored.FolderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+		} else if folder == nil || folder.ProjectID != request.ProjectID {
+			restored.FolderID = request.FolderID
+		}
+	}
+
+	if err = s.Store.UpdateRequest(ctx, authData.UserID, &restored); err != nil {
+		if _, ok := err.(errors.Conflict); ok {
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not restore request", Err: err}
+	}
+
+	return &RestoreRequestRevisionOutput{
+		Request: &restored,
+	}, nil
+}
+
+// MakeRestoreRequestRevisionEndpoint creates the endpoint
+func MakeRestoreRequestRevisionEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*RestoreRequestRevisionInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.RestoreRequestRevision(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
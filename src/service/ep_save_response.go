@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// SaveResponseInput is the input of the endpoint, it contains a response of a request
+type SaveResponseInput struct {
+	RequestID  string         `json:"request_id" validate:"required"`
+	Name       string         `json:"name" validate:"required"`
+	StatusCode int            `json:"status_code" validate:"min=100,max=599"`
+	Headers    []*store.Param `json:"headers" validate:"-"`
+	Body       string         `json:"body" validate:"-"`
+}
+
+// SaveResponseOutput is the output of the endpoint
+type SaveResponseOutput struct {
+	Response *store.Response `json:"response"`
+}
+
+// SaveResponse implements the business logic for the endpoint
+func (s *Service) SaveResponse(ctx context.Context, input *SaveResponseInput) (*SaveResponseOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// get request
+	request, err := s.Store.GetRequestByID(ctx, input.RequestID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get request", Err: err}
+	} else if request == nil {
+		return nil, errors.NotFound{Obj: "Request"}
+	}
+
+	// check if the user has access to the project of the request
+	if err := s.checkAccessToProject(ctx, authData.UserID, request.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	if len(input.Body) > store.MaxResponseBodySize {
+		return nil, errors.BadRequest{Msg: "The body of the response is too large"}
+	}
+
+	// create response
+	response := &store.Response{
+		ID:         s.Store.NewResponseID(),
+		Name:       strings.TrimSpace(input.Name),
+		RequestID:  request.ID,
+		ProjectID:  request.ProjectID,
+		StatusCode: input.StatusCode,
+		Headers:    input.Headers,
+		Body:       input.Body,
+	}
+
+	if response.Headers == nil {
+		response.Headers = []*store.Param{}
+	}
+
+	if err := s.Store.CreateResponse(ctx, authData.UserID, response); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not save response", Err: err}
+	}
+
+	return &SaveResponseOutput{
+		Response: response,
+	}, nil
+}
+
+// MakeSaveResponseEndpoint creates the endpoint
+func MakeSaveResponseEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*SaveResponseInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.SaveResponse(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/executor"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// StartRunInput is the input of the endpoint, the whole project runs when the folder is not included.
+// The requests run once per row of the data file (an uploaded CSV or JSON file, optional),
+// and the concurrency is the number of iterations that run at the same time (1 by default).
+// The changes of the environment are only saved when the concurrency is 1.
+type StartRunInput struct {
+	ProjectID     string `json:"project_id" validate:"required"`
+	FolderID      string `json:"folder_id" validate:"-"`
+	EnvironmentID string `json:"environment_id" validate:"-"`
+	StopOnFailure bool   `json:"stop_on_failure" validate:"-"`
+	DataBlobID    string `json:"data_blob_id" validate:"-"`
+	Concurrency   int    `json:"concurrency" validate:"omitempty,min=1"`
+}
+
+// StartRunOutput is the output of the endpoint, the progress of the run is polled with the get run endpoint
+type StartRunOutput struct {
+	Run *store.Run `json:"run"`
+}
+
+// StartRun implements the business logic for the endpoint
+func (s *Service) StartRun(ctx context.Context, input *StartRunInput) (*StartRunOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	// get project
+	project, err := s.Store.GetProjectByID(ctx, input.ProjectID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, errors.NotFound{Obj: "Project"}
+	}
+
+	// get environment (if included)
+	var environment *store.Environment
+
+	if input.EnvironmentID != "" {
+		environment, err = s.Store.GetEnvironmentByID(ctx, input.EnvironmentID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get environment", Err: err}
+		} else if environment == nil || environment.ProjectID != project.ID {
+			return nil, errors.NotFound{Obj: "Environment"}
+		}
+	}
+
+	// check the concurrency
+	if input.Concurrency > s.Config.MaxRunConcurrency {
+		return nil, errors.BadRequest{Msg: fmt.Sprintf("The concurrency can't be greater than %d", s.Config.MaxRunConcurrency)}
+	}
+
+	// get the rows of the data file (if included)
+	var data []map[string]string
+
+	if input.DataBlobID != "" {
+		blob, err := s.Store.GetBlobByID(ctx, input.DataBlobID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get blob", Err: err}
+		} else if blob == nil || blob.ProjectID != project.ID {
+			return nil, errors.NotFound{Obj: "Blob"}
+		}
+
+		if data, err = executor.ParseDataFile(blob.FileName, blob.ContentType, blob.Data); err != nil {
+			return nil, errors.BadRequest{Msg: "Invalid data file: " + err.Error()}
+		} else if len(data) == 0 {
+			return nil, errors.BadRequest{Msg: "The data file has no rows"}
+		} else if len(data) > s.Config.MaxRunIterations {
+			return nil, errors.BadRequest{Msg: fmt.Sprintf("The data file can't have more than %d rows", s.Config.MaxRunIterations)}
+		}
+	}
+
+	// get the requests of the folder (if included) or the project, in the order of the tree
+	tree, err := s.getFolderTree(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []*runStep{}
+	visited := map[string]bool{}
+
+	if input.FolderID != "" {
+		folder, err := s.Store.GetFolderByID(ctx, input.FolderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+		} else if folder == nil || folder.ProjectID != project.ID {
+			return nil, errors.NotFound{Obj: "Folder"}
+		}
+
+		chain, err := s.getFolderChain(ctx, folder)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = tree.runSteps(folder, chain[1:], visited)
+	} else {
+		for _, folder := range tree.children[""] {
+			steps = append(steps, tree.runSteps(folder, nil, visited)...)
+		}
+	}
+
+	// create run, the runs without data file have a single iteration
+	count := len(data)
+	if count == 0 {
+		count = 1
+	}
+
+	if input.Concurrency == 0 {
+		input.Concurrency = 1
+	}
+
+	run := &store.Run{
+		ID:             s.Store.NewRunID(),
+		ProjectID:      project.ID,
+		FolderID:       input.FolderID,
+		EnvironmentID:  input.EnvironmentID,
+		StopOnFailure:  input.StopOnFailure,
+		DataBlobID:     input.DataBlobID,
+		Concurrency:    input.Concurrency,
+		Status:         enums.RunStatusQueued,
+		Total:          len(steps) * count,
+		IterationCount: count,
+	}
+
+	if err := s.Store.CreateRun(ctx, authData.UserID, run); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not create run", Err: err}
+	}
+
+	// queue the run, the workers execute it in the background
+	job := &runJob{
+		run:         run,
+		steps:       steps,
+		data:        data,
+		project:     project,
+		environment: environment,
+	}
+
+	if !s.runs.push(ctx, job) {
+		s.failRun(job, errors.BadRequest{Msg: "Too many runs in progress"})
+		return nil, errors.BadRequest{Msg: "Too many runs in progress, try again later"}
+	}
+
+	return &StartRunOutput{
+		Run: run,
+	}, nil
+}
+
+// MakeStartRunEndpoint creates the endpoint
+func MakeStartRunEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*StartRunInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.StartRun(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
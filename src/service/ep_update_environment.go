@@ -11,11 +11,13 @@ import (
 	"github.com/go-kit/kit/endpoint"
 )
 
-// UpdateEnvironmentInput is the input of the endpoint
+// UpdateEnvironmentInput is the input of the endpoint, the fields that are not included are not modified
+// and the variables are merged with the stored ones (a null value removes a variable)
 type UpdateEnvironmentInput struct {
-	ID        string            `json:"id" validate:"required"`
-	Name      string            `json:"name" validate:"required"`
-	Variables map[string]string `json:"variables" validate:"-"`
+	ID        string             `json:"id" validate:"required"`
+	Name      *string            `json:"name" validate:"omitempty,min=1"`
+	Variables map[string]*string `json:"variables" validate:"-"`
+	Version   int64              `json:"version" validate:"omitempty,min=1"`
 }
 
 // UpdateEnvironmentOutput is the output of the endpoint
@@ -41,11 +43,23 @@ func (s *Service) UpdateEnvironment(ctx context.Context, input *UpdateEnvironmen
 		return nil, err
 	}
 
-	// update environment
-	environment.Name = strings.TrimSpace(input.Name)
-	environment.Variables = input.Variables
+	// update the fields included in the input, the update fails
+	// if the environment was modified after the version known by the client
+	environment, err = s.Store.PatchEnvironment(ctx, authData.UserID, environment.ID, input.Version, func(environment *store.Environment) {
+		if input.Name != nil {
+			environment.Name = strings.TrimSpace(*input.Name)
+		}
+
+		if input.Variables != nil {
+			environment.Variables = mergeStringMap(environment.Variables, input.Variables)
+		}
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
 
-	if err = s.Store.UpdateEnvironment(ctx, authData.UserID, environment); err != nil {
 		return nil, errors.InternalServer{Msg: "Could not update environment", Err: err}
 	}
 
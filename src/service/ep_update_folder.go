@@ -11,10 +11,19 @@ import (
 	"github.com/go-kit/kit/endpoint"
 )
 
-// UpdateFolderInput is the input of the endpoint
+// UpdateFolderInput is the input of the endpoint, the fields that are not included are not modified
+// and the variables are merged with the stored ones (a null value removes a variable)
 type UpdateFolderInput struct {
-	ID   string `json:"id" validate:"required"`
-	Name string `json:"name" validate:"required"`
+	ID               string             `json:"id" validate:"required"`
+	Name             *string            `json:"name" validate:"omitempty,min=1"`
+	ParentFolderID   *string            `json:"parent_folder_id" validate:"-"`
+	BaseURL          *string            `json:"base_url" validate:"-"`
+	Headers          []*store.Param     `json:"headers" validate:"-"`
+	Variables        map[string]*string `json:"variables" validate:"-"`
+	Auth             *store.Auth        `json:"auth" validate:"-"`
+	PreRequestScript *string            `json:"pre_request_script" validate:"-"`
+	TestScript       *string            `json:"test_script" validate:"-"`
+	Version          int64              `json:"version" validate:"omitempty,min=1"`
 }
 
 // UpdateFolderOutput is the output of the endpoint
@@ -40,10 +49,84 @@ func (s *Service) UpdateFolder(ctx context.Context, input *UpdateFolderInput) (*
 		return nil, err
 	}
 
-	// update folder
-	folder.Name = strings.TrimSpace(input.Name)
+	// check if the folder can be moved to the parent folder (if changed), it is placed at the end of the parent folder
+	rank := folder.Rank
+	moved := input.ParentFolderID != nil && folder.ParentFolderID != *input.ParentFolderID
+	levels := 0
+
+	if moved {
+		if levels, err = s.checkFolderMove(ctx, folder, *input.ParentFolderID); err != nil {
+			return nil, err
+		}
+
+		rank, err = s.rankFolder(ctx, authData.UserID, folder.ProjectID, *input.ParentFolderID, folder.ID, "", "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	// check the auth settings
+	if err := checkAuth(input.Auth, true); err != nil {
+		return nil, err
+	}
+
+	// check the scripts
+	if err := checkScripts(input.PreRequestScript, input.TestScript); err != nil {
+		return nil, err
+	}
+
+	// update the fields included in the input, the update fails if the folder was modified
+	// after the version known by the client, and the parent folders are checked again when it is moved
+	patch := func(folder *store.Folder) {
+		if input.Name != nil {
+			folder.Name = strings.TrimSpace(*input.Name)
+		}
+
+		if moved {
+			folder.Rank = rank
+		}
+
+		if input.BaseURL != nil {
+			folder.BaseURL = *input.BaseURL
+		}
+
+		if input.Headers != nil {
+			folder.Headers = input.Headers
+		}
+
+		if input.Variables != nil {
+			folder.Variables = mergeStringMap(folder.Variables, input.Variables)
+		}
+
+		if input.Auth != nil {
+			folder.Auth = input.Auth
+		}
+
+		if input.PreRequestScript != nil {
+			folder.PreRequestScript = *input.PreRequestScript
+		}
+
+		if input.TestScript != nil {
+			folder.TestScript = *input.TestScript
+		}
+	}
+
+	if moved {
+		folder, err = s.Store.MoveFolder(ctx, authData.UserID, folder.ID, input.Version, *input.ParentFolderID, levels, s.Config.MaxFolderDepth, patch)
+	} else {
+		folder, err = s.Store.PatchFolder(ctx, authData.UserID, folder.ID, input.Version, patch)
+	}
+	if err != nil {
+		switch err.(type) {
+		case errors.BadRequest, errors.Conflict, errors.NotFound:
+			return nil, err
+		}
 
-	if err = s.Store.UpdateFolder(ctx, authData.UserID, folder); err != nil {
 		return nil, errors.InternalServer{Msg: "Could not update folder", Err: err}
 	}
 
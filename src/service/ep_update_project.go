@@ -11,10 +11,16 @@ import (
 	"github.com/go-kit/kit/endpoint"
 )
 
-// UpdateProjectInput is the input of the endpoint
+// UpdateProjectInput is the input of the endpoint, the fields that are not included are not modified
+// and the variables are merged with the stored ones (a null value removes a variable)
 type UpdateProjectInput struct {
-	ID   string `json:"id" validate:"required"`
-	Name string `json:"name" validate:"required"`
+	ID        string             `json:"id" validate:"required"`
+	Name      *string            `json:"name" validate:"omitempty,min=1"`
+	BaseURL   *string            `json:"base_url" validate:"-"`
+	Headers   []*store.Param     `json:"headers" validate:"-"`
+	Variables map[string]*string `json:"variables" validate:"-"`
+	Auth      *store.Auth        `json:"auth" validate:"-"`
+	Version   int64              `json:"version" validate:"omitempty,min=1"`
 }
 
 // UpdateProjectOutput is the output of the endpoint
@@ -40,10 +46,45 @@ func (s *Service) UpdateProject(ctx context.Context, input *UpdateProjectInput)
 		return nil, errors.NotFound{Obj: "Project"}
 	}
 
-	// update project
-	project.Name = strings.TrimSpace(input.Name)
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
+	}
+
+	// check the auth settings
+	if err := checkAuth(input.Auth, false); err != nil {
+		return nil, err
+	}
+
+	// update the fields included in the input, the update fails
+	// if the project was modified after the version known by the client
+	project, err = s.Store.PatchProject(ctx, authData.UserID, project.ID, input.Version, func(project *store.Project) {
+		if input.Name != nil {
+			project.Name = strings.TrimSpace(*input.Name)
+		}
+
+		if input.BaseURL != nil {
+			project.BaseURL = *input.BaseURL
+		}
+
+		if input.Headers != nil {
+			project.Headers = input.Headers
+		}
+
+		if input.Variables != nil {
+			project.Variables = mergeStringMap(project.Variables, input.Variables)
+		}
+
+		if input.Auth != nil {
+			project.Auth = input.Auth
+		}
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
 
-	if err = s.Store.UpdateProject(ctx, authData.UserID, project); err != nil {
 		return nil, errors.InternalServer{Msg: "Could not update project", Err: err}
 	}
 
@@ -6,20 +6,34 @@ import (
 
 	"apiboy/backend/src/errors"
 	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/requestutils"
 	"apiboy/backend/src/store"
 
 	"github.com/go-kit/kit/endpoint"
 )
 
-// UpdateRequestInput is the input of the endpoint
+// UpdateRequestInput is the input of the endpoint, the fields that are not included are not modified
 type UpdateRequestInput struct {
-	ID       string            `json:"id" validate:"required"`
-	Name     string            `json:"name" validate:"required"`
-	FolderID string            `json:"folder_id" validate:"required"`
-	Type     string            `json:"type" validate:"omitempty,request_type"`
-	URL      string            `json:"url" validate:"-"`
-	Headers  map[string]string `json:"headers" validate:"-"`
-	Body     string            `json:"body" validate:"-"`
+	ID               string                 `json:"id" validate:"required"`
+	Name             *string                `json:"name" validate:"omitempty,min=1"`
+	FolderID         *string                `json:"folder_id" validate:"omitempty,min=1"`
+	Type             *string                `json:"type" validate:"omitempty,request_type"`
+	URL              *string                `json:"url" validate:"-"`
+	QueryParams      []*store.Param         `json:"query_params" validate:"-"`
+	Headers          []*store.Param         `json:"headers" validate:"-"`
+	BodyMode         *string                `json:"body_mode" validate:"omitempty,body_mode"`
+	Body             *string                `json:"body" validate:"-"`
+	BodyContentType  *string                `json:"body_content_type" validate:"-"`
+	FormParams       []*store.Param         `json:"form_params" validate:"-"`
+	MultipartParts   []*store.MultipartPart `json:"multipart_parts" validate:"-"`
+	BinaryBlobID     *string                `json:"binary_blob_id" validate:"-"`
+	GraphQL          *store.GraphQLBody     `json:"graphql" validate:"-"`
+	Auth             *store.Auth            `json:"auth" validate:"-"`
+	Assertions       []*store.Assertion     `json:"assertions" validate:"-"`
+	Captures         []*store.Capture       `json:"captures" validate:"-"`
+	PreRequestScript *string                `json:"pre_request_script" validate:"-"`
+	TestScript       *string                `json:"test_script" validate:"-"`
+	Version          int64                  `json:"version" validate:"omitempty,min=1"`
 }
 
 // UpdateRequestOutput is the output of the endpoint
@@ -45,9 +59,12 @@ func (s *Service) UpdateRequest(ctx context.Context, input *UpdateRequestInput)
 		return nil, err
 	}
 
-	// check if the folder exists and is a folder of the same project (if changed)
-	if request.FolderID != input.FolderID {
-		folder, err := s.Store.GetFolderByID(ctx, input.FolderID)
+	// check if the folder exists and is a folder of the same project (if changed),
+	// the request is placed at the end of the folder
+	rank := request.Rank
+
+	if input.FolderID != nil && request.FolderID != *input.FolderID {
+		folder, err := s.Store.GetFolderByID(ctx, *input.FolderID)
 		if err != nil {
 			return nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
 		} else if folder == nil {
@@ -57,17 +74,144 @@ func (s *Service) UpdateRequest(ctx context.Context, input *UpdateRequestInput)
 		if request.ProjectID != folder.ProjectID {
 			return nil, errors.BadRequest{Msg: "Invalid folder for project"}
 		}
+
+		rank, err = s.rankRequest(ctx, authData.UserID, folder.ID, request.ID, "", "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// check the form params
+	if err := checkParams(input.FormParams, "form param"); err != nil {
+		return nil, err
+	}
+
+	// check the files and the GraphQL variables of the body
+	binaryBlobID := ""
+	if input.BinaryBlobID != nil {
+		binaryBlobID = *input.BinaryBlobID
+	}
+
+	if err := s.checkRequestBody(ctx, request.ProjectID, input.MultipartParts, binaryBlobID, input.GraphQL); err != nil {
+		return nil, err
+	}
+
+	// check the query params
+	if err := checkParams(input.QueryParams, "query param"); err != nil {
+		return nil, err
+	}
+
+	// check the headers
+	if err := checkParams(input.Headers, "header"); err != nil {
+		return nil, err
 	}
 
-	// update request
-	request.Name = strings.TrimSpace(input.Name)
-	request.FolderID = input.FolderID
-	request.Type = input.Type
-	request.URL = input.URL
-	request.Headers = input.Headers
-	request.Body = input.Body
+	// check the auth settings
+	if err := checkAuth(input.Auth, true); err != nil {
+		return nil, err
+	}
+
+	// check the assertions
+	if err := checkAssertions(input.Assertions); err != nil {
+		return nil, err
+	}
+
+	// check the captures
+	if err := checkCaptures(input.Captures); err != nil {
+		return nil, err
+	}
+
+	// check the scripts
+	if err := checkScripts(input.PreRequestScript, input.TestScript); err != nil {
+		return nil, err
+	}
+
+	// update the fields included in the input, the update fails
+	// if the request was modified after the version known by the client
+	request, err = s.Store.PatchRequest(ctx, authData.UserID, request.ID, input.Version, func(request *store.Request) {
+		if input.Name != nil {
+			request.Name = strings.TrimSpace(*input.Name)
+		}
+
+		if input.FolderID != nil && request.FolderID != *input.FolderID {
+			request.FolderID = *input.FolderID
+			request.Rank = rank
+		}
+
+		if input.Type != nil {
+			request.Type = *input.Type
+		}
+
+		if input.URL != nil {
+			request.URL = *input.URL
+		}
+
+		// keep the url and the query params in sync
+		if input.QueryParams != nil {
+			request.QueryParams = input.QueryParams
+			request.URL = requestutils.SetQueryParams(request.URL, request.QueryParams)
+		} else if input.URL != nil {
+			request.QueryParams = requestutils.ParseQueryParams(request.URL, request.QueryParams)
+		}
+
+		if input.Headers != nil {
+			request.Headers = input.Headers
+		}
+
+		if input.BodyMode != nil {
+			request.BodyMode = *input.BodyMode
+		}
+
+		if input.Body != nil {
+			request.Body = *input.Body
+		}
+
+		if input.BodyContentType != nil {
+			request.BodyContentType = *input.BodyContentType
+		}
+
+		if input.FormParams != nil {
+			request.FormParams = input.FormParams
+		}
+
+		if input.MultipartParts != nil {
+			request.MultipartParts = input.MultipartParts
+		}
+
+		if input.BinaryBlobID != nil {
+			request.BinaryBlobID = *input.BinaryBlobID
+		}
+
+		if input.GraphQL != nil {
+			request.GraphQL = input.GraphQL
+		}
+
+		if input.Auth != nil {
+			request.Auth = input.Auth
+		}
+
+		if input.Assertions != nil {
+			request.Assertions = input.Assertions
+		}
+
+		if input.Captures != nil {
+			request.Captures = input.Captures
+		}
+
+		if input.PreRequestScript != nil {
+			request.PreRequestScript = *input.PreRequestScript
+		}
+
+		if input.TestScript != nil {
+			request.TestScript = *input.TestScript
+		}
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.Conflict, errors.NotFound:
+			return nil, err
+		}
 
-	if err = s.Store.UpdateRequest(ctx, authData.UserID, request); err != nil {
 		return nil, errors.InternalServer{Msg: "Could not update request", Err: err}
 	}
 
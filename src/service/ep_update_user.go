@@ -43,6 +43,11 @@ func (s *Service) UpdateUser(ctx context.Context, input *UpdateUserInput) (*Upda
 		return nil, errors.Unauthorized{}
 	}
 
+	// the tokens of the password resets can only change the password of the user
+	if authData.PasswordReset && (input.ID != authData.UserID || password == "") {
+		return nil, errors.BadRequest{Msg: "The password must be reset"}
+	}
+
 	// get user
 	user, err := s.Store.GetUserByID(ctx, input.ID)
 	if err != nil {
@@ -72,6 +77,14 @@ func (s *Service) UpdateUser(ctx context.Context, input *UpdateUserInput) (*Upda
 		password = hashedPassword
 	}
 
+	// a new password completes a forced reset, and then the user logs in again with it
+	resetCompleted := user.PasswordResetRequired && password != user.Password
+
+	if resetCompleted {
+		user.PasswordResetRequired = false
+		user.TempCode = ""
+	}
+
 	user.Name = name
 	user.Email = email
 	user.Password = password
@@ -80,6 +93,12 @@ func (s *Service) UpdateUser(ctx context.Context, input *UpdateUserInput) (*Upda
 		return nil, errors.InternalServer{Msg: "Could not update user", Err: err}
 	}
 
+	if resetCompleted {
+		if err = s.Store.DeleteTokensByUserID(ctx, user.ID); err != nil {
+			return nil, errors.InternalServer{Msg: "Could not delete tokens", Err: err}
+		}
+	}
+
 	return &UpdateUserOutput{
 		User: user,
 	}, nil
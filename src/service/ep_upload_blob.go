@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/store"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// UploadBlobInput is the input of the endpoint, the data is encoded in base64
+type UploadBlobInput struct {
+	ProjectID   string `json:"project_id" validate:"required"`
+	FileName    string `json:"file_name" validate:"required"`
+	ContentType string `json:"content_type" validate:"-"`
+	Data        []byte `json:"data" validate:"required"`
+}
+
+// UploadBlobOutput is the output of the endpoint
+type UploadBlobOutput struct {
+	Blob *store.Blob `json:"blob"`
+}
+
+// UploadBlob implements the business logic for the endpoint
+func (s *Service) UploadBlob(ctx context.Context, input *UploadBlobInput) (*UploadBlobOutput, error) {
+	// get the auth data from the context
+	authData := httputils.GetContextAuthData(ctx)
+
+	// check if the user has access to the project
+	if err := s.checkAccessToProject(ctx, authData.UserID, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if len(input.Data) > store.MaxBlobSize {
+		return nil, errors.BadRequest{Msg: "The file is too big"}
+	}
+
+	// create blob
+	blob := &store.Blob{
+		ID:          s.Store.NewBlobID(),
+		ProjectID:   input.ProjectID,
+		FileName:    strings.TrimSpace(input.FileName),
+		ContentType: strings.TrimSpace(input.ContentType),
+		Data:        input.Data,
+	}
+
+	if err := s.Store.CreateBlob(ctx, authData.UserID, blob); err != nil {
+		return nil, errors.InternalServer{Msg: "Could not create blob", Err: err}
+	}
+
+	return &UploadBlobOutput{
+		Blob: blob,
+	}, nil
+}
+
+// MakeUploadBlobEndpoint creates the endpoint
+func MakeUploadBlobEndpoint(s *Service, m ...endpoint.Middleware) endpoint.Endpoint {
+	e := func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		input, ok := request.(*UploadBlobInput)
+		if !ok {
+			return nil, errors.BadRequest{}
+		}
+
+		return s.UploadBlob(ctx, input)
+	}
+
+	for _, mw := range m {
+		e = mw(e)
+	}
+
+	return e
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/rankutils"
+	"apiboy/backend/src/store"
+)
+
+// FolderNode is a folder of the tree of a project, with its subfolders and requests
+type FolderNode struct {
+	Folder   *store.Folder    `json:"folder"`
+	Folders  []*FolderNode    `json:"folders"`
+	Requests []*store.Request `json:"requests"`
+}
+
+// folderTree indexes the folders and requests of a project by their parent folder
+type folderTree struct {
+	children map[string][]*store.Folder
+	requests map[string][]*store.Request
+}
+
+// newFolderTree returns a new folderTree, the folders and requests are sorted by rank
+func newFolderTree(folders []*store.Folder, requests []*store.Request) *folderTree {
+	t := &folderTree{
+		children: map[string][]*store.Folder{},
+		requests: map[string][]*store.Request{},
+	}
+
+	sort.SliceStable(folders, func(i, j int) bool {
+		return rankutils.Less(folders[i].Rank, folders[i].Name, folders[j].Rank, folders[j].Name)
+	})
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return rankutils.Less(requests[i].Rank, requests[i].Name, requests[j].Rank, requests[j].Name)
+	})
+
+	for _, folder := range folders {
+		t.children[folder.ParentFolderID] = append(t.children[folder.ParentFolderID], folder)
+	}
+
+	for _, request := range requests {
+		t.requests[request.FolderID] = append(t.requests[request.FolderID], request)
+	}
+
+	return t
+}
+
+// subtree returns a folder and all of its subfolders, the parents before their children
+func (t *folderTree) subtree(folder *store.Folder) []*store.Folder {
+	folders := []*store.Folder{folder}
+	visited := map[string]bool{folder.ID: true}
+
+	for i := 0; i < len(folders); i++ {
+		for _, child := range t.children[folders[i].ID] {
+			if !visited[child.ID] {
+				visited[child.ID] = true
+				folders = append(folders, child)
+			}
+		}
+	}
+
+	return folders
+}
+
+// subtreeRequests returns the requests of the given folders
+func (t *folderTree) subtreeRequests(folders []*store.Folder) []*store.Request {
+	requests := []*store.Request{}
+
+	for _, folder := range folders {
+		requests = append(requests, t.requests[folder.ID]...)
+	}
+
+	return requests
+}
+
+// height returns the number of levels of a folder and its subfolders
+func (t *folderTree) height(folder *store.Folder) int {
+	levels := map[string]int{folder.ID: 1}
+	height := 1
+
+	for _, f := range t.subtree(folder) {
+		for _, child := range t.children[f.ID] {
+			if _, ok := levels[child.ID]; !ok {
+				levels[child.ID] = levels[f.ID] + 1
+
+				if levels[child.ID] > height {
+					height = levels[child.ID]
+				}
+			}
+		}
+	}
+
+	return height
+}
+
+// nodes returns the tree of the subfolders of a folder, or of the root of the project
+func (t *folderTree) nodes(parentFolderID string, visited map[string]bool) []*FolderNode {
+	nodes := []*FolderNode{}
+
+	for _, folder := range t.children[parentFolderID] {
+		if visited[folder.ID] {
+			continue
+		}
+		visited[folder.ID] = true
+
+		requests := t.requests[folder.ID]
+		if requests == nil {
+			requests = []*store.Request{}
+		}
+
+		nodes = append(nodes, &FolderNode{
+			Folder:   folder,
+			Folders:  t.nodes(folder.ID, visited),
+			Requests: requests,
+		})
+	}
+
+	return nodes
+}
+
+// getFolderTree gets the tree of the folders and requests of a project
+func (s *Service) getFolderTree(ctx context.Context, projectID string) (*folderTree, error) {
+	folders, err := s.Store.GetFoldersByProjectID(ctx, projectID, false)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folders", Err: err}
+	}
+
+	requests, err := s.Store.GetRequestsByProjectID(ctx, projectID, false)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get requests", Err: err}
+	}
+
+	return newFolderTree(folders, requests), nil
+}
+
+// getFolderChain returns a folder and its parent folders, from the nearest to the farthest
+func (s *Service) getFolderChain(ctx context.Context, folder *store.Folder) ([]*store.Folder, error) {
+	chain := []*store.Folder{folder}
+	visited := map[string]bool{folder.ID: true}
+
+	for folder.ParentFolderID != "" && !visited[folder.ParentFolderID] {
+		parent, err := s.Store.GetFolderByID(ctx, folder.ParentFolderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get parent folder", Err: err}
+		} else if parent == nil {
+			break
+		}
+
+		chain = append(chain, parent)
+		visited[parent.ID] = true
+		folder = parent
+	}
+
+	return chain, nil
+}
+
+// getParentChain gets the parent folder for a folder of a project and its parent folders, from the
+// nearest to the farthest, and checks if a folder with the given number of levels fits in it.
+// The root of the project has no folders.
+func (s *Service) getParentChain(ctx context.Context, projectID, parentFolderID string, levels int) ([]*store.Folder, error) {
+	chain := []*store.Folder{}
+
+	if parentFolderID != "" {
+		parent, err := s.Store.GetFolderByID(ctx, parentFolderID)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get parent folder", Err: err}
+		} else if parent == nil {
+			return nil, errors.NotFound{Obj: "Parent folder"}
+		}
+
+		if parent.ProjectID != projectID {
+			return nil, errors.BadRequest{Msg: "Invalid parent folder for project"}
+		}
+
+		chain, err = s.getFolderChain(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(chain)+levels > s.Config.MaxFolderDepth {
+		return nil, errors.BadRequest{Msg: "Maximum folder depth exceeded"}
+	}
+
+	return chain, nil
+}
+
+// checkFolderMove validates if a folder can be moved to a parent folder of its project, a folder can't
+// be moved into itself or its subfolders, and the moved subfolders can't exceed the maximum depth.
+// It returns the levels of the folder and its subfolders, so the move is checked again when it is written.
+func (s *Service) checkFolderMove(ctx context.Context, folder *store.Folder, parentFolderID string) (int, error) {
+	tree, err := s.getFolderTree(ctx, folder.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+
+	levels := tree.height(folder)
+
+	chain, err := s.getParentChain(ctx, folder.ProjectID, parentFolderID, levels)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, parent := range chain {
+		if parent.ID == folder.ID {
+			return 0, errors.BadRequest{Msg: "Invalid parent folder, a folder can't be moved into itself"}
+		}
+	}
+
+	return levels, nil
+}
+
+// cloneFolderTree changes the ids of a subtree of folders and their requests to copy them to a project,
+// the first folder is the top of the subtree and it is placed in the given parent folder
+func (s *Service) cloneFolderTree(folders []*store.Folder, requests []*store.Request, projectID, parentFolderID string) {
+	folderIDs := map[string]string{}
+
+	for i, folder := range folders {
+		folderIDs[folder.ID] = s.Store.NewFolderID()
+		folder.ID = folderIDs[folder.ID]
+		folder.ProjectID = projectID
+		folder.Updated = nil
+
+		if i == 0 {
+			folder.ParentFolderID = parentFolderID
+		} else {
+			folder.ParentFolderID = folderIDs[folder.ParentFolderID]
+		}
+	}
+
+	for _, request := range requests {
+		request.ID = s.Store.NewRequestID()
+		request.FolderID = folderIDs[request.FolderID]
+		request.ProjectID = projectID
+		request.Updated = nil
+	}
+}
@@ -1,34 +1,79 @@
 package service
 
 import (
+	"apiboy/backend/src/enums"
+
 	"github.com/go-kit/kit/endpoint"
 )
 
 // HTTPEndpoints collects all of the endpoints that are exposed through http
 type HTTPEndpoints struct {
-	GetFirebaseCredentialsEndpoint endpoint.Endpoint
-	LoginEndpoint                  endpoint.Endpoint
-	LogoutEndpoint                 endpoint.Endpoint
-	SignupEndpoint                 endpoint.Endpoint
-	ResetPasswordEndpoint          endpoint.Endpoint
-	UpdateUserEndpoint             endpoint.Endpoint
-	DeleteUserEndpoint             endpoint.Endpoint
-	CreateProjectEndpoint          endpoint.Endpoint
-	UpdateProjectEndpoint          endpoint.Endpoint
-	DeleteProjectEndpoint          endpoint.Endpoint
-	CreateProjectUserEndpoint      endpoint.Endpoint
-	DeleteProjectUserEndpoint      endpoint.Endpoint
-	CreateFolderEndpoint           endpoint.Endpoint
-	DeleteFolderEndpoint           endpoint.Endpoint
-	UpdateFolderEndpoint           endpoint.Endpoint
-	CreateRequestEndpoint          endpoint.Endpoint
-	UpdateRequestEndpoint          endpoint.Endpoint
-	DeleteRequestEndpoint          endpoint.Endpoint
-	DuplicateRequestEndpoint       endpoint.Endpoint
-	CreateEnvironmentEndpoint      endpoint.Endpoint
-	UpdateEnvironmentEndpoint      endpoint.Endpoint
-	DeleteEnvironmentEndpoint      endpoint.Endpoint
-	DuplicateEnvironmentEndpoint   endpoint.Endpoint
+	GetFirebaseCredentialsEndpoint     endpoint.Endpoint
+	LoginEndpoint                      endpoint.Endpoint
+	LogoutEndpoint                     endpoint.Endpoint
+	SignupEndpoint                     endpoint.Endpoint
+	ResetPasswordEndpoint              endpoint.Endpoint
+	UpdateUserEndpoint                 endpoint.Endpoint
+	DeleteUserEndpoint                 endpoint.Endpoint
+	AdminSearchUsersEndpoint           endpoint.Endpoint
+	AdminGetUserEndpoint               endpoint.Endpoint
+	AdminDisableUserEndpoint           endpoint.Endpoint
+	AdminEnableUserEndpoint            endpoint.Endpoint
+	AdminForceResetPasswordEndpoint    endpoint.Endpoint
+	AdminSetUserRoleEndpoint           endpoint.Endpoint
+	AdminGetAuditEndpoint              endpoint.Endpoint
+	CreateProjectEndpoint              endpoint.Endpoint
+	UpdateProjectEndpoint              endpoint.Endpoint
+	DeleteProjectEndpoint              endpoint.Endpoint
+	DuplicateProjectEndpoint           endpoint.Endpoint
+	ImportPostmanEndpoint              endpoint.Endpoint
+	ImportOpenAPIEndpoint              endpoint.Endpoint
+	ImportHAREndpoint                  endpoint.Endpoint
+	ImportInsomniaEndpoint             endpoint.Endpoint
+	ExportPostmanEndpoint              endpoint.Endpoint
+	ExportOpenAPIEndpoint              endpoint.Endpoint
+	ExportHAREndpoint                  endpoint.Endpoint
+	GetProjectAuditEndpoint            endpoint.Endpoint
+	GetProjectTreeEndpoint             endpoint.Endpoint
+	CreateProjectUserEndpoint          endpoint.Endpoint
+	DeleteProjectUserEndpoint          endpoint.Endpoint
+	CreateFolderEndpoint               endpoint.Endpoint
+	DeleteFolderEndpoint               endpoint.Endpoint
+	UpdateFolderEndpoint               endpoint.Endpoint
+	DuplicateFolderEndpoint            endpoint.Endpoint
+	RestoreFolderEndpoint              endpoint.Endpoint
+	ReorderFolderEndpoint              endpoint.Endpoint
+	MoveFolderEndpoint                 endpoint.Endpoint
+	CopyFolderEndpoint                 endpoint.Endpoint
+	CreateRequestEndpoint              endpoint.Endpoint
+	UpdateRequestEndpoint              endpoint.Endpoint
+	DeleteRequestEndpoint              endpoint.Endpoint
+	DuplicateRequestEndpoint           endpoint.Endpoint
+	ReorderRequestEndpoint             endpoint.Endpoint
+	MoveRequestEndpoint                endpoint.Endpoint
+	CopyRequestEndpoint                endpoint.Endpoint
+	ImportCurlEndpoint                 endpoint.Endpoint
+	ResolveRequestEndpoint             endpoint.Endpoint
+	GetRequestSnippetEndpoint          endpoint.Endpoint
+	ExecuteRequestEndpoint             endpoint.Endpoint
+	UploadBlobEndpoint                 endpoint.Endpoint
+	SaveResponseEndpoint               endpoint.Endpoint
+	DeleteResponseEndpoint             endpoint.Endpoint
+	ListRequestRevisionsEndpoint       endpoint.Endpoint
+	DiffRequestRevisionsEndpoint       endpoint.Endpoint
+	RestoreRequestRevisionEndpoint     endpoint.Endpoint
+	CreateEnvironmentEndpoint          endpoint.Endpoint
+	UpdateEnvironmentEndpoint          endpoint.Endpoint
+	DeleteEnvironmentEndpoint          endpoint.Endpoint
+	DuplicateEnvironmentEndpoint       endpoint.Endpoint
+	MoveEnvironmentEndpoint            endpoint.Endpoint
+	CopyEnvironmentEndpoint            endpoint.Endpoint
+	ListEnvironmentRevisionsEndpoint   endpoint.Endpoint
+	DiffEnvironmentRevisionsEndpoint   endpoint.Endpoint
+	RestoreEnvironmentRevisionEndpoint endpoint.Endpoint
+	StartRunEndpoint                   endpoint.Endpoint
+	GetRunEndpoint                     endpoint.Endpoint
+	CancelRunEndpoint                  endpoint.Endpoint
 }
 
 // MakeHTTPEndpoints returns an HTTPEndpoints struct where each endpoint invokes
@@ -40,29 +85,81 @@ func MakeHTTPEndpoints(s *Service) HTTPEndpoints {
 	// Authentication middleware
 	am := s.NewAuthMiddleware()
 
+	// Authentication middleware that accepts the tokens of the password resets
+	prm := s.NewPasswordResetAuthMiddleware()
+
+	// Administrators middleware
+	adm := s.NewAdminMiddleware()
+
+	// Audit middleware
+	audit := s.NewAuditMiddleware
+
 	return HTTPEndpoints{
-		GetFirebaseCredentialsEndpoint: MakeGetFirebaseCredentialsEndpoint(s, vm, am),
-		LoginEndpoint:                  MakeLoginEndpoint(s, vm),
-		LogoutEndpoint:                 MakeLogoutEndpoint(s, vm, am),
-		SignupEndpoint:                 MakeSignupEndpoint(s, vm),
-		ResetPasswordEndpoint:          MakeResetPasswordEndpoint(s, vm),
-		UpdateUserEndpoint:             MakeUpdateUserEndpoint(s, vm, am),
-		DeleteUserEndpoint:             MakeDeleteUserEndpoint(s, vm, am),
-		CreateProjectEndpoint:          MakeCreateProjectEndpoint(s, vm, am),
-		UpdateProjectEndpoint:          MakeUpdateProjectEndpoint(s, vm, am),
-		DeleteProjectEndpoint:          MakeDeleteProjectEndpoint(s, vm, am),
-		CreateProjectUserEndpoint:      MakeCreateProjectUserEndpoint(s, vm, am),
-		DeleteProjectUserEndpoint:      MakeDeleteProjectUserEndpoint(s, vm, am),
-		CreateFolderEndpoint:           MakeCreateFolderEndpoint(s, vm, am),
-		DeleteFolderEndpoint:           MakeDeleteFolderEndpoint(s, vm, am),
-		UpdateFolderEndpoint:           MakeUpdateFolderEndpoint(s, vm, am),
-		CreateRequestEndpoint:          MakeCreateRequestEndpoint(s, vm, am),
-		UpdateRequestEndpoint:          MakeUpdateRequestEndpoint(s, vm, am),
-		DeleteRequestEndpoint:          MakeDeleteRequestEndpoint(s, vm, am),
-		DuplicateRequestEndpoint:       MakeDuplicateRequestEndpoint(s, vm, am),
-		CreateEnvironmentEndpoint:      MakeCreateEnvironmentEndpoint(s, vm, am),
-		UpdateEnvironmentEndpoint:      MakeUpdateEnvironmentEndpoint(s, vm, am),
-		DeleteEnvironmentEndpoint:      MakeDeleteEnvironmentEndpoint(s, vm, am),
-		DuplicateEnvironmentEndpoint:   MakeDuplicateEnvironmentEndpoint(s, vm, am),
+		GetFirebaseCredentialsEndpoint:     MakeGetFirebaseCredentialsEndpoint(s, vm, am),
+		LoginEndpoint:                      MakeLoginEndpoint(s, vm),
+		LogoutEndpoint:                     MakeLogoutEndpoint(s, vm, prm),
+		SignupEndpoint:                     MakeSignupEndpoint(s, vm),
+		ResetPasswordEndpoint:              MakeResetPasswordEndpoint(s, vm),
+		UpdateUserEndpoint:                 MakeUpdateUserEndpoint(s, audit(enums.AuditActionUpdateUser, enums.EntityTypeUser), vm, prm),
+		DeleteUserEndpoint:                 MakeDeleteUserEndpoint(s, audit(enums.AuditActionDeleteUser, enums.EntityTypeUser), vm, am),
+		AdminSearchUsersEndpoint:           MakeAdminSearchUsersEndpoint(s, vm, adm, am),
+		AdminGetUserEndpoint:               MakeAdminGetUserEndpoint(s, vm, adm, am),
+		AdminDisableUserEndpoint:           MakeAdminDisableUserEndpoint(s, audit(enums.AuditActionDisableUser, enums.EntityTypeUser), vm, adm, am),
+		AdminEnableUserEndpoint:            MakeAdminEnableUserEndpoint(s, audit(enums.AuditActionEnableUser, enums.EntityTypeUser), vm, adm, am),
+		AdminForceResetPasswordEndpoint:    MakeAdminForceResetPasswordEndpoint(s, audit(enums.AuditActionForceResetPassword, enums.EntityTypeUser), vm, adm, am),
+		AdminSetUserRoleEndpoint:           MakeAdminSetUserRoleEndpoint(s, audit(enums.AuditActionSetUserRole, enums.EntityTypeUser), vm, adm, am),
+		AdminGetAuditEndpoint:              MakeAdminGetAuditEndpoint(s, vm, adm, am),
+		CreateProjectEndpoint:              MakeCreateProjectEndpoint(s, audit(enums.AuditActionCreateProject, enums.EntityTypeProject), vm, am),
+		UpdateProjectEndpoint:              MakeUpdateProjectEndpoint(s, audit(enums.AuditActionUpdateProject, enums.EntityTypeProject), vm, am),
+		DeleteProjectEndpoint:              MakeDeleteProjectEndpoint(s, audit(enums.AuditActionDeleteProject, enums.EntityTypeProject), vm, am),
+		DuplicateProjectEndpoint:           MakeDuplicateProjectEndpoint(s, audit(enums.AuditActionDuplicateProject, enums.EntityTypeProject), vm, am),
+		ImportPostmanEndpoint:              MakeImportPostmanEndpoint(s, audit(enums.AuditActionImportProject, enums.EntityTypeProject), vm, am),
+		ImportOpenAPIEndpoint:              MakeImportOpenAPIEndpoint(s, audit(enums.AuditActionImportProject, enums.EntityTypeProject), vm, am),
+		ImportHAREndpoint:                  MakeImportHAREndpoint(s, audit(enums.AuditActionImportProject, enums.EntityTypeProject), vm, am),
+		ImportInsomniaEndpoint:             MakeImportInsomniaEndpoint(s, vm, am),
+		ExportPostmanEndpoint:              MakeExportPostmanEndpoint(s, vm, am),
+		ExportOpenAPIEndpoint:              MakeExportOpenAPIEndpoint(s, vm, am),
+		ExportHAREndpoint:                  MakeExportHAREndpoint(s, vm, am),
+		GetProjectAuditEndpoint:            MakeGetProjectAuditEndpoint(s, vm, am),
+		GetProjectTreeEndpoint:             MakeGetProjectTreeEndpoint(s, vm, am),
+		CreateProjectUserEndpoint:          MakeCreateProjectUserEndpoint(s, audit(enums.AuditActionCreateProjectUser, enums.EntityTypeProjectUser), vm, am),
+		DeleteProjectUserEndpoint:          MakeDeleteProjectUserEndpoint(s, audit(enums.AuditActionDeleteProjectUser, enums.EntityTypeProjectUser), vm, am),
+		CreateFolderEndpoint:               MakeCreateFolderEndpoint(s, audit(enums.AuditActionCreateFolder, enums.EntityTypeFolder), vm, am),
+		DeleteFolderEndpoint:               MakeDeleteFolderEndpoint(s, audit(enums.AuditActionDeleteFolder, enums.EntityTypeFolder), vm, am),
+		UpdateFolderEndpoint:               MakeUpdateFolderEndpoint(s, audit(enums.AuditActionUpdateFolder, enums.EntityTypeFolder), vm, am),
+		DuplicateFolderEndpoint:            MakeDuplicateFolderEndpoint(s, audit(enums.AuditActionDuplicateFolder, enums.EntityTypeFolder), vm, am),
+		RestoreFolderEndpoint:              MakeRestoreFolderEndpoint(s, audit(enums.AuditActionRestoreFolder, enums.EntityTypeFolder), vm, am),
+		ReorderFolderEndpoint:              MakeReorderFolderEndpoint(s, audit(enums.AuditActionReorderFolder, enums.EntityTypeFolder), vm, am),
+		MoveFolderEndpoint:                 MakeMoveFolderEndpoint(s, audit(enums.AuditActionMoveFolder, enums.EntityTypeFolder), vm, am),
+		CopyFolderEndpoint:                 MakeCopyFolderEndpoint(s, audit(enums.AuditActionCopyFolder, enums.EntityTypeFolder), vm, am),
+		CreateRequestEndpoint:              MakeCreateRequestEndpoint(s, audit(enums.AuditActionCreateRequest, enums.EntityTypeRequest), vm, am),
+		UpdateRequestEndpoint:              MakeUpdateRequestEndpoint(s, audit(enums.AuditActionUpdateRequest, enums.EntityTypeRequest), vm, am),
+		DeleteRequestEndpoint:              MakeDeleteRequestEndpoint(s, audit(enums.AuditActionDeleteRequest, enums.EntityTypeRequest), vm, am),
+		DuplicateRequestEndpoint:           MakeDuplicateRequestEndpoint(s, audit(enums.AuditActionDuplicateRequest, enums.EntityTypeRequest), vm, am),
+		ReorderRequestEndpoint:             MakeReorderRequestEndpoint(s, audit(enums.AuditActionReorderRequest, enums.EntityTypeRequest), vm, am),
+		MoveRequestEndpoint:                MakeMoveRequestEndpoint(s, audit(enums.AuditActionMoveRequest, enums.EntityTypeRequest), vm, am),
+		CopyRequestEndpoint:                MakeCopyRequestEndpoint(s, audit(enums.AuditActionCopyRequest, enums.EntityTypeRequest), vm, am),
+		ImportCurlEndpoint:                 MakeImportCurlEndpoint(s, audit(enums.AuditActionImportRequest, enums.EntityTypeRequest), vm, am),
+		ResolveRequestEndpoint:             MakeResolveRequestEndpoint(s, vm, am),
+		GetRequestSnippetEndpoint:          MakeGetRequestSnippetEndpoint(s, vm, am),
+		ExecuteRequestEndpoint:             MakeExecuteRequestEndpoint(s, vm, am),
+		UploadBlobEndpoint:                 MakeUploadBlobEndpoint(s, audit(enums.AuditActionUploadBlob, enums.EntityTypeBlob), vm, am),
+		SaveResponseEndpoint:               MakeSaveResponseEndpoint(s, audit(enums.AuditActionSaveResponse, enums.EntityTypeResponse), vm, am),
+		DeleteResponseEndpoint:             MakeDeleteResponseEndpoint(s, audit(enums.AuditActionDeleteResponse, enums.EntityTypeResponse), vm, am),
+		ListRequestRevisionsEndpoint:       MakeListRequestRevisionsEndpoint(s, vm, am),
+		DiffRequestRevisionsEndpoint:       MakeDiffRequestRevisionsEndpoint(s, vm, am),
+		RestoreRequestRevisionEndpoint:     MakeRestoreRequestRevisionEndpoint(s, audit(enums.AuditActionRestoreRequestRevision, enums.EntityTypeRequest), vm, am),
+		CreateEnvironmentEndpoint:          MakeCreateEnvironmentEndpoint(s, audit(enums.AuditActionCreateEnvironment, enums.EntityTypeEnvironment), vm, am),
+		UpdateEnvironmentEndpoint:          MakeUpdateEnvironmentEndpoint(s, audit(enums.AuditActionUpdateEnvironment, enums.EntityTypeEnvironment), vm, am),
+		DeleteEnvironmentEndpoint:          MakeDeleteEnvironmentEndpoint(s, audit(enums.AuditActionDeleteEnvironment, enums.EntityTypeEnvironment), vm, am),
+		DuplicateEnvironmentEndpoint:       MakeDuplicateEnvironmentEndpoint(s, audit(enums.AuditActionDuplicateEnvironment, enums.EntityTypeEnvironment), vm, am),
+		MoveEnvironmentEndpoint:            MakeMoveEnvironmentEndpoint(s, audit(enums.AuditActionMoveEnvironment, enums.EntityTypeEnvironment), vm, am),
+		CopyEnvironmentEndpoint:            MakeCopyEnvironmentEndpoint(s, audit(enums.AuditActionCopyEnvironment, enums.EntityTypeEnvironment), vm, am),
+		ListEnvironmentRevisionsEndpoint:   MakeListEnvironmentRevisionsEndpoint(s, vm, am),
+		DiffEnvironmentRevisionsEndpoint:   MakeDiffEnvironmentRevisionsEndpoint(s, vm, am),
+		RestoreEnvironmentRevisionEndpoint: MakeRestoreEnvironmentRevisionEndpoint(s, audit(enums.AuditActionRestoreEnvironmentRevision, enums.EntityTypeEnvironment), vm, am),
+		StartRunEndpoint:                   MakeStartRunEndpoint(s, audit(enums.AuditActionStartRun, enums.EntityTypeRun), vm, am),
+		GetRunEndpoint:                     MakeGetRunEndpoint(s, vm, am),
+		CancelRunEndpoint:                  MakeCancelRunEndpoint(s, audit(enums.AuditActionCancelRun, enums.EntityTypeRun), vm, am),
 	}
 }
@@ -79,6 +79,55 @@ func MakeHTTPHandler(ctx context.Context, log *logger.Logger, e HTTPEndpoints) h
 		defaultOptions...,
 	)).Name("DeleteUser")
 
+	r.Methods("POST").Path("/admin/users/search").Handler(kithttp.NewServer(
+		e.AdminSearchUsersEndpoint,
+		httputils.DecodeRPCRequest(&AdminSearchUsersInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminSearchUsers")
+
+	r.Methods("POST").Path("/admin/users/get").Handler(kithttp.NewServer(
+		e.AdminGetUserEndpoint,
+		httputils.DecodeRPCRequest(&AdminGetUserInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminGetUser")
+
+	r.Methods("POST").Path("/admin/users/disable").Handler(kithttp.NewServer(
+		e.AdminDisableUserEndpoint,
+		httputils.DecodeRPCRequest(&AdminDisableUserInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminDisableUser")
+
+	r.Methods("POST").Path("/admin/users/enable").Handler(kithttp.NewServer(
+		e.AdminEnableUserEndpoint,
+		httputils.DecodeRPCRequest(&AdminEnableUserInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminEnableUser")
+
+	r.Methods("POST").Path("/admin/users/force_reset_password").Handler(kithttp.NewServer(
+		e.AdminForceResetPasswordEndpoint,
+		httputils.DecodeRPCRequest(&AdminForceResetPasswordInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminForceResetPassword")
+
+	r.Methods("POST").Path("/admin/users/set_role").Handler(kithttp.NewServer(
+		e.AdminSetUserRoleEndpoint,
+		httputils.DecodeRPCRequest(&AdminSetUserRoleInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminSetUserRole")
+
+	r.Methods("POST").Path("/admin/audit").Handler(kithttp.NewServer(
+		e.AdminGetAuditEndpoint,
+		httputils.DecodeRPCRequest(&AdminGetAuditInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("AdminGetAudit")
+
 	r.Methods("POST").Path("/projects/create").Handler(kithttp.NewServer(
 		e.CreateProjectEndpoint,
 		httputils.DecodeRPCRequest(&CreateProjectInput{}),
@@ -100,6 +149,76 @@ func MakeHTTPHandler(ctx context.Context, log *logger.Logger, e HTTPEndpoints) h
 		defaultOptions...,
 	)).Name("DeleteProject")
 
+	r.Methods("POST").Path("/projects/duplicate").Handler(kithttp.NewServer(
+		e.DuplicateProjectEndpoint,
+		httputils.DecodeRPCRequest(&DuplicateProjectInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("DuplicateProject")
+
+	r.Methods("POST").Path("/projects/import/postman").Handler(kithttp.NewServer(
+		e.ImportPostmanEndpoint,
+		httputils.DecodeRPCRequest(&ImportPostmanInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ImportPostman")
+
+	r.Methods("POST").Path("/projects/import/openapi").Handler(kithttp.NewServer(
+		e.ImportOpenAPIEndpoint,
+		httputils.DecodeRPCRequest(&ImportOpenAPIInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ImportOpenAPI")
+
+	r.Methods("POST").Path("/projects/import/har").Handler(kithttp.NewServer(
+		e.ImportHAREndpoint,
+		httputils.DecodeRPCRequest(&ImportHARInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ImportHAR")
+
+	r.Methods("POST").Path("/projects/import/insomnia").Handler(kithttp.NewServer(
+		e.ImportInsomniaEndpoint,
+		httputils.DecodeRPCRequest(&ImportInsomniaInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ImportInsomnia")
+
+	r.Methods("POST").Path("/projects/export/postman").Handler(kithttp.NewServer(
+		e.ExportPostmanEndpoint,
+		httputils.DecodeRPCRequest(&ExportPostmanInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ExportPostman")
+
+	r.Methods("POST").Path("/projects/export/openapi").Handler(kithttp.NewServer(
+		e.ExportOpenAPIEndpoint,
+		httputils.DecodeRPCRequest(&ExportOpenAPIInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ExportOpenAPI")
+
+	r.Methods("POST").Path("/projects/export/har").Handler(kithttp.NewServer(
+		e.ExportHAREndpoint,
+		httputils.DecodeRPCRequest(&ExportHARInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ExportHAR")
+
+	r.Methods("POST").Path("/projects/audit").Handler(kithttp.NewServer(
+		e.GetProjectAuditEndpoint,
+		httputils.DecodeRPCRequest(&GetProjectAuditInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("GetProjectAudit")
+
+	r.Methods("POST").Path("/projects/tree").Handler(kithttp.NewServer(
+		e.GetProjectTreeEndpoint,
+		httputils.DecodeRPCRequest(&GetProjectTreeInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("GetProjectTree")
+
 	r.Methods("POST").Path("/projects-users/create").Handler(kithttp.NewServer(
 		e.CreateProjectUserEndpoint,
 		httputils.DecodeRPCRequest(&CreateProjectUserInput{}),
@@ -135,6 +254,41 @@ func MakeHTTPHandler(ctx context.Context, log *logger.Logger, e HTTPEndpoints) h
 		defaultOptions...,
 	)).Name("UpdateFolder")
 
+	r.Methods("POST").Path("/folders/duplicate").Handler(kithttp.NewServer(
+		e.DuplicateFolderEndpoint,
+		httputils.DecodeRPCRequest(&DuplicateFolderInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("DuplicateFolder")
+
+	r.Methods("POST").Path("/folders/restore").Handler(kithttp.NewServer(
+		e.RestoreFolderEndpoint,
+		httputils.DecodeRPCRequest(&RestoreFolderInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("RestoreFolder")
+
+	r.Methods("POST").Path("/folders/reorder").Handler(kithttp.NewServer(
+		e.ReorderFolderEndpoint,
+		httputils.DecodeRPCRequest(&ReorderFolderInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ReorderFolder")
+
+	r.Methods("POST").Path("/folders/move").Handler(kithttp.NewServer(
+		e.MoveFolderEndpoint,
+		httputils.DecodeRPCRequest(&MoveFolderInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("MoveFolder")
+
+	r.Methods("POST").Path("/folders/copy").Handler(kithttp.NewServer(
+		e.CopyFolderEndpoint,
+		httputils.DecodeRPCRequest(&CopyFolderInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("CopyFolder")
+
 	r.Methods("POST").Path("/requests/create").Handler(kithttp.NewServer(
 		e.CreateRequestEndpoint,
 		httputils.DecodeRPCRequest(&CreateRequestInput{}),
@@ -163,6 +317,97 @@ func MakeHTTPHandler(ctx context.Context, log *logger.Logger, e HTTPEndpoints) h
 		defaultOptions...,
 	)).Name("DuplicateRequest")
 
+	r.Methods("POST").Path("/requests/reorder").Handler(kithttp.NewServer(
+		e.ReorderRequestEndpoint,
+		httputils.DecodeRPCRequest(&ReorderRequestInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ReorderRequest")
+
+	r.Methods("POST").Path("/requests/move").Handler(kithttp.NewServer(
+		e.MoveRequestEndpoint,
+		httputils.DecodeRPCRequest(&MoveRequestInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("MoveRequest")
+
+	r.Methods("POST").Path("/requests/copy").Handler(kithttp.NewServer(
+		e.CopyRequestEndpoint,
+		httputils.DecodeRPCRequest(&CopyRequestInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("CopyRequest")
+
+	r.Methods("POST").Path("/requests/import/curl").Handler(kithttp.NewServer(
+		e.ImportCurlEndpoint,
+		httputils.DecodeRPCRequest(&ImportCurlInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ImportCurl")
+
+	r.Methods("POST").Path("/requests/resolve").Handler(kithttp.NewServer(
+		e.ResolveRequestEndpoint,
+		httputils.DecodeRPCRequest(&ResolveRequestInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ResolveRequest")
+
+	r.Methods("POST").Path("/requests/snippet").Handler(kithttp.NewServer(
+		e.GetRequestSnippetEndpoint,
+		httputils.DecodeRPCRequest(&GetRequestSnippetInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("GetRequestSnippet")
+
+	r.Methods("POST").Path("/requests/execute").Handler(kithttp.NewServer(
+		e.ExecuteRequestEndpoint,
+		httputils.DecodeRPCRequest(&ExecuteRequestInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ExecuteRequest")
+
+	r.Methods("POST").Path("/blobs/upload").Handler(kithttp.NewServer(
+		e.UploadBlobEndpoint,
+		httputils.DecodeRPCRequest(&UploadBlobInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("UploadBlob")
+
+	r.Methods("POST").Path("/responses/save").Handler(kithttp.NewServer(
+		e.SaveResponseEndpoint,
+		httputils.DecodeRPCRequest(&SaveResponseInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("SaveResponse")
+
+	r.Methods("POST").Path("/responses/delete").Handler(kithttp.NewServer(
+		e.DeleteResponseEndpoint,
+		httputils.DecodeRPCRequest(&DeleteResponseInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("DeleteResponse")
+
+	r.Methods("POST").Path("/requests/revisions/list").Handler(kithttp.NewServer(
+		e.ListRequestRevisionsEndpoint,
+		httputils.DecodeRPCRequest(&ListRequestRevisionsInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ListRequestRevisions")
+
+	r.Methods("POST").Path("/requests/revisions/diff").Handler(kithttp.NewServer(
+		e.DiffRequestRevisionsEndpoint,
+		httputils.DecodeRPCRequest(&DiffRequestRevisionsInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("DiffRequestRevisions")
+
+	r.Methods("POST").Path("/requests/revisions/restore").Handler(kithttp.NewServer(
+		e.RestoreRequestRevisionEndpoint,
+		httputils.DecodeRPCRequest(&RestoreRequestRevisionInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("RestoreRequestRevision")
+
 	r.Methods("POST").Path("/environments/create").Handler(kithttp.NewServer(
 		e.CreateEnvironmentEndpoint,
 		httputils.DecodeRPCRequest(&CreateEnvironmentInput{}),
@@ -191,6 +436,62 @@ func MakeHTTPHandler(ctx context.Context, log *logger.Logger, e HTTPEndpoints) h
 		defaultOptions...,
 	)).Name("DuplicateEnvironment")
 
+	r.Methods("POST").Path("/environments/move").Handler(kithttp.NewServer(
+		e.MoveEnvironmentEndpoint,
+		httputils.DecodeRPCRequest(&MoveEnvironmentInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("MoveEnvironment")
+
+	r.Methods("POST").Path("/environments/copy").Handler(kithttp.NewServer(
+		e.CopyEnvironmentEndpoint,
+		httputils.DecodeRPCRequest(&CopyEnvironmentInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("CopyEnvironment")
+
+	r.Methods("POST").Path("/environments/revisions/list").Handler(kithttp.NewServer(
+		e.ListEnvironmentRevisionsEndpoint,
+		httputils.DecodeRPCRequest(&ListEnvironmentRevisionsInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("ListEnvironmentRevisions")
+
+	r.Methods("POST").Path("/environments/revisions/diff").Handler(kithttp.NewServer(
+		e.DiffEnvironmentRevisionsEndpoint,
+		httputils.DecodeRPCRequest(&DiffEnvironmentRevisionsInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("DiffEnvironmentRevisions")
+
+	r.Methods("POST").Path("/environments/revisions/restore").Handler(kithttp.NewServer(
+		e.RestoreEnvironmentRevisionEndpoint,
+		httputils.DecodeRPCRequest(&RestoreEnvironmentRevisionInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("RestoreEnvironmentRevision")
+
+	r.Methods("POST").Path("/runs/start").Handler(kithttp.NewServer(
+		e.StartRunEndpoint,
+		httputils.DecodeRPCRequest(&StartRunInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("StartRun")
+
+	r.Methods("POST").Path("/runs/get").Handler(kithttp.NewServer(
+		e.GetRunEndpoint,
+		httputils.DecodeRPCRequest(&GetRunInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("GetRun")
+
+	r.Methods("POST").Path("/runs/cancel").Handler(kithttp.NewServer(
+		e.CancelRunEndpoint,
+		httputils.DecodeRPCRequest(&CancelRunInput{}),
+		httputils.ResponseEncoder(log),
+		defaultOptions...,
+	)).Name("CancelRun")
+
 	/*******************************************/
 
 	// NotFound Handler: catch any other request with this handler
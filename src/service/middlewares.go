@@ -9,13 +9,26 @@ import (
 	"apiboy/backend/src/enums"
 	"apiboy/backend/src/errors"
 	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/logger"
 
 	"github.com/go-kit/kit/endpoint"
 	validatorV9 "gopkg.in/go-playground/validator.v9"
 )
 
-// NewAuthMiddleware returns an endpoint middleware to handle authentication
+// NewAuthMiddleware returns an endpoint middleware to handle authentication,
+// it rejects the tokens of the password resets
 func (s *Service) NewAuthMiddleware() endpoint.Middleware {
+	return s.newAuthMiddleware(false)
+}
+
+// NewPasswordResetAuthMiddleware returns an endpoint middleware to handle authentication
+// that accepts the tokens of the password resets, for the endpoints used to change the password
+func (s *Service) NewPasswordResetAuthMiddleware() endpoint.Middleware {
+	return s.newAuthMiddleware(true)
+}
+
+// newAuthMiddleware returns an endpoint middleware to handle authentication
+func (s *Service) newAuthMiddleware(allowPasswordReset bool) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 			// get http request from context
@@ -41,6 +54,12 @@ func (s *Service) NewAuthMiddleware() endpoint.Middleware {
 				return nil, errors.Unauthenticated{Msg: "Invalid token for the user"}
 			}
 
+			if token.PasswordReset && !allowPasswordReset {
+				return nil, errors.Unauthorized{Msg: "The password must be reset"}
+			}
+
+			authData.PasswordReset = token.PasswordReset
+
 			// populate context with auth data
 			ctx = httputils.SetContextAuthData(ctx, authData)
 			return next(ctx, request)
@@ -48,6 +67,60 @@ func (s *Service) NewAuthMiddleware() endpoint.Middleware {
 	}
 }
 
+// NewAdminMiddleware returns an endpoint middleware to restrict access to administrators,
+// it must be applied after the authentication middleware
+func (s *Service) NewAdminMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			// get the auth data from the context
+			authData := httputils.GetContextAuthData(ctx)
+
+			if authData.UserRole != enums.UserRoleAdmin {
+				return nil, errors.Unauthorized{Msg: "The user is not an administrator"}
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// NewAuditMiddleware returns an endpoint middleware to record the changes done by an endpoint in the audit log,
+// it must be the first middleware of the endpoint so it runs after the authentication
+func (s *Service) NewAuditMiddleware(action, entityType string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			// get the state of the entity before the change
+			before, err := s.getAuditEntity(ctx, entityType, request)
+			if err != nil {
+				return nil, errors.InternalServer{Msg: "Could not get entity for audit", Err: err}
+			}
+
+			response, err = next(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the state after the change is included in the response
+			after := getOutputEntity(response)
+
+			// a different entity means that a new one was created from the referenced entity
+			if before != nil && after != nil && getStringField(before, "ID") != getStringField(after, "ID") {
+				before = nil
+			}
+
+			// the change is already done, so a failure to record it must not fail the request
+			if err := s.recordAuditEntry(ctx, action, entityType, before, after); err != nil {
+				s.Logger.Error("Could not create audit entry",
+					logger.Field{Key: "action", Val: action},
+					logger.Field{Key: "err", Val: err},
+				)
+			}
+
+			return response, nil
+		}
+	}
+}
+
 // NewInputValidationMiddleware returns an endpoint middleware to handle input validations
 func (s *Service) NewInputValidationMiddleware() endpoint.Middleware {
 	inputValidator := validatorV9.New()
@@ -69,6 +142,30 @@ func (s *Service) NewInputValidationMiddleware() endpoint.Middleware {
 		return enums.IsValidRequestType(value)
 	})
 
+	inputValidator.RegisterValidation("body_mode", func(fl validatorV9.FieldLevel) bool {
+		value := fl.Field().String()
+
+		return enums.IsValidBodyMode(value)
+	})
+
+	inputValidator.RegisterValidation("user_role", func(fl validatorV9.FieldLevel) bool {
+		value := fl.Field().String()
+
+		return enums.IsValidUserRole(value)
+	})
+
+	inputValidator.RegisterValidation("entity_type", func(fl validatorV9.FieldLevel) bool {
+		value := fl.Field().String()
+
+		return enums.IsValidEntityType(value)
+	})
+
+	inputValidator.RegisterValidation("snippet_language", func(fl validatorV9.FieldLevel) bool {
+		value := fl.Field().String()
+
+		return enums.IsValidSnippetLanguage(value)
+	})
+
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 			if err := inputValidator.Struct(request); err != nil {
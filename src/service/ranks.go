@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/rankutils"
+)
+
+// rankedItem is a folder or request sorted by rank among its siblings
+type rankedItem struct {
+	ID   string
+	Rank string
+	Name string
+}
+
+// placeRank returns the rank to place an item before or after one of its siblings, or at the end
+// if no sibling is included. If the siblings don't have unique ranks, they are ranked again and
+// their new ranks are returned mapped by id. The ranks have a random suffix, so the items placed
+// at the same time in the same position get different ranks.
+func placeRank(siblings []*rankedItem, beforeID, afterID string) (string, map[string]string, error) {
+	if beforeID != "" && afterID != "" {
+		return "", nil, errors.BadRequest{Msg: "Invalid position, only one sibling can be included"}
+	}
+
+	sort.SliceStable(siblings, func(i, j int) bool {
+		return rankutils.Less(siblings[i].Rank, siblings[i].Name, siblings[j].Rank, siblings[j].Name)
+	})
+
+	n := len(siblings)
+
+	if beforeID == "" && afterID == "" {
+		last := ""
+		if n > 0 {
+			last = siblings[n-1].Rank
+		}
+
+		return rankutils.Random(last, ""), nil, nil
+	}
+
+	// find the sibling
+	i := -1
+	for j, sibling := range siblings {
+		if sibling.ID == beforeID || sibling.ID == afterID {
+			i = j
+		}
+	}
+
+	if i < 0 {
+		return "", nil, errors.BadRequest{Msg: "Invalid sibling"}
+	}
+
+	// the ranks of the siblings around the position
+	bounds := func() (string, string) {
+		if beforeID != "" {
+			if i == 0 {
+				return "", siblings[i].Rank
+			}
+
+			return siblings[i-1].Rank, siblings[i].Rank
+		}
+
+		if i == n-1 {
+			return siblings[i].Rank, ""
+		}
+
+		return siblings[i].Rank, siblings[i+1].Rank
+	}
+
+	a, b := bounds()
+	atEnd := afterID != "" && i == n-1
+
+	var ranked map[string]string
+
+	if !atEnd && (b == "" || a >= b) {
+		ranked = map[string]string{}
+
+		for j, rank := range rankutils.Sequence(n) {
+			siblings[j].Rank = rank
+			ranked[siblings[j].ID] = rank
+		}
+
+		a, b = bounds()
+	}
+
+	return rankutils.Random(a, b), ranked, nil
+}
+
+// rankFolder returns the rank for a folder placed before or after a sibling of a parent folder
+// (or at the end), and saves the new ranks of the siblings if they are ranked again
+func (s *Service) rankFolder(ctx context.Context, userID, projectID, parentFolderID, folderID, beforeID, afterID string) (string, error) {
+	folders, err := s.Store.GetFoldersByParentFolderID(ctx, projectID, parentFolderID)
+	if err != nil {
+		return "", errors.InternalServer{Msg: "Could not get folders", Err: err}
+	}
+
+	siblings := []*rankedItem{}
+	for _, folder := range folders {
+		if folder.ID != folderID {
+			siblings = append(siblings, &rankedItem{ID: folder.ID, Rank: folder.Rank, Name: folder.Name})
+		}
+	}
+
+	rank, ranked, err := placeRank(siblings, beforeID, afterID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ranked) > 0 {
+		if err := s.Store.UpdateFolderRanks(ctx, userID, ranked); err != nil {
+			return "", errors.InternalServer{Msg: "Could not update folder ranks", Err: err}
+		}
+	}
+
+	return rank, nil
+}
+
+// rankRequest returns the rank for a request placed before or after a sibling of a folder
+// (or at the end), and saves the new ranks of the siblings if they are ranked again
+func (s *Service) rankRequest(ctx context.Context, userID, folderID, requestID, beforeID, afterID string) (string, error) {
+	requests, err := s.Store.GetRequestsByFolderID(ctx, folderID)
+	if err != nil {
+		return "", errors.InternalServer{Msg: "Could not get requests", Err: err}
+	}
+
+	siblings := []*rankedItem{}
+	for _, request := range requests {
+		if request.ID != requestID {
+			siblings = append(siblings, &rankedItem{ID: request.ID, Rank: request.Rank, Name: request.Name})
+		}
+	}
+
+	rank, ranked, err := placeRank(siblings, beforeID, afterID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ranked) > 0 {
+		if err := s.Store.UpdateRequestRanks(ctx, userID, ranked); err != nil {
+			return "", errors.InternalServer{Msg: "Could not update request ranks", Err: err}
+		}
+	}
+
+	return rank, nil
+}
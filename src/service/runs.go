@@ -0,0 +1,485 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/executor"
+	"apiboy/backend/src/httputils"
+	"apiboy/backend/src/logger"
+	"apiboy/backend/src/store"
+)
+
+const (
+	// runQueueSize is the maximum number of runs waiting for a worker
+	runQueueSize = 100
+
+	// runHeartbeatInterval is the interval between the updates of the heartbeats of the runs of an instance
+	runHeartbeatInterval = 30 * time.Second
+
+	// runHeartbeatTimeout is the time after the last heartbeat when a run is considered stopped,
+	// like when the instance executing it restarts
+	runHeartbeatTimeout = 2 * time.Minute
+)
+
+// runStep is a request executed by a run, with its folders from the nearest to the farthest
+type runStep struct {
+	Request *store.Request
+	Folders []*store.Folder
+}
+
+// runJob is a run waiting for a worker, with the requests to execute and the rows of its data file
+type runJob struct {
+	ctx         context.Context
+	run         *store.Run
+	steps       []*runStep
+	data        []map[string]string
+	project     *store.Project
+	environment *store.Environment
+}
+
+// runQueue contains the runs waiting for a worker, and cancels the runs in progress
+type runQueue struct {
+	jobs    chan *runJob
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// newRunQueue returns a new runQueue
+func newRunQueue() *runQueue {
+	return &runQueue{
+		jobs:    make(chan *runJob, runQueueSize),
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// push adds a run to the queue, it returns false if the queue is full. The run keeps
+// the values of the context, like the auth data, but not its cancellation.
+func (q *runQueue) push(ctx context.Context, job *runJob) bool {
+	ctx, cancel := context.WithCancel(detachedContext{ctx})
+	job.ctx = ctx
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case q.jobs <- job:
+		q.cancels[job.run.ID] = cancel
+		return true
+	default:
+		cancel()
+		return false
+	}
+}
+
+// cancel cancels a run if it is queued or in progress in this instance
+func (q *runQueue) cancel(id string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if cancel, ok := q.cancels[id]; ok {
+		cancel()
+	}
+}
+
+// done releases the context of a finished run
+func (q *runQueue) done(id string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if cancel, ok := q.cancels[id]; ok {
+		cancel()
+		delete(q.cancels, id)
+	}
+}
+
+// ids returns the ids of the runs queued or in progress in this instance
+func (q *runQueue) ids() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ids := []string{}
+	for id := range q.cancels {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// detachedContext keeps the values of a context without its deadline and cancellation,
+// so the runs continue after the response of the endpoint that started them
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// startRunWorkers starts the workers that execute the queued runs, and updates the heartbeats
+// of the runs queued or in progress, so the other instances know they are not stopped
+func (s *Service) startRunWorkers(workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range s.runs.jobs {
+				s.executeRun(job)
+				s.runs.done(job.run.ID)
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(runHeartbeatInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ids := s.runs.ids()
+			if len(ids) == 0 {
+				continue
+			}
+
+			if err := s.Store.UpdateRunHeartbeats(context.Background(), ids); err != nil {
+				s.Logger.Error("Could not update run heartbeats", logger.Field{Key: "err", Val: err})
+			}
+		}
+	}()
+}
+
+// finishStaleRun finishes with an error a run whose heartbeat expired, because the instance executing it stopped.
+// It returns the run as it is if it is not stale.
+func (s *Service) finishStaleRun(ctx context.Context, run *store.Run) (*store.Run, error) {
+	if !run.IsStale(runHeartbeatTimeout) {
+		return run, nil
+	}
+
+	userID := ""
+	if run.Created != nil {
+		userID = run.Created.By
+	}
+
+	return s.Store.PatchRun(ctx, run.ID, func(run *store.Run) {
+		if run.IsStale(runHeartbeatTimeout) {
+			run.Status = enums.RunStatusError
+			run.Error = "The run was interrupted"
+			run.Finished = store.NewEvent(userID)
+		}
+	})
+}
+
+// runSteps returns the requests of a folder and its subfolders in the order of the tree, the requests
+// of each folder run before its subfolders. The parents are the parent folders of the folder.
+func (t *folderTree) runSteps(folder *store.Folder, parents []*store.Folder, visited map[string]bool) []*runStep {
+	if visited[folder.ID] {
+		return nil
+	}
+	visited[folder.ID] = true
+
+	folders := append([]*store.Folder{folder}, parents...)
+	steps := []*runStep{}
+
+	for _, request := range t.requests[folder.ID] {
+		steps = append(steps, &runStep{Request: request, Folders: folders})
+	}
+
+	for _, child := range t.children[folder.ID] {
+		steps = append(steps, t.runSteps(child, folders, visited)...)
+	}
+
+	return steps
+}
+
+// runState is the state shared by the iterations of a run in progress, the environment
+// is only shared when the iterations run one at a time
+type runState struct {
+	mu          sync.Mutex
+	environment *store.Environment
+	failed      bool
+	stopped     bool
+	err         error
+}
+
+// isStopped returns if the iterations must stop
+func (st *runState) isStopped() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.stopped
+}
+
+// stop stops the iterations, the error is the first internal error of the iterations (optional)
+func (st *runState) stop(err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.stopped = true
+	if st.err == nil {
+		st.err = err
+	}
+}
+
+// executeRun executes the iterations of a run, and saves its progress after each request.
+// When the iterations run one at a time, the changes of the environment made by the scripts and the
+// captures are saved in the environment of the run, so the next requests can use them. When they run
+// at the same time, each iteration keeps its changes in its own copy of the environment, so they don't
+// overwrite each other, and they are not saved. The run stops when it is cancelled, and at the first
+// failed request if the run stops on failures.
+func (s *Service) executeRun(job *runJob) {
+	// the progress is saved even if the run is cancelled while a request is sent
+	ctx := job.ctx
+	storeCtx := detachedContext{ctx}
+	userID := httputils.GetContextAuthData(ctx).UserID
+
+	run, err := s.Store.PatchRun(storeCtx, job.run.ID, func(run *store.Run) {
+		if run.Status == enums.RunStatusQueued {
+			run.Status = enums.RunStatusRunning
+			run.Started = store.NewEvent(userID)
+		}
+	})
+	if err != nil {
+		s.failRun(job, fmt.Errorf("could not start run: %v", err))
+		return
+	}
+
+	state := &runState{
+		environment: job.environment,
+		stopped:     run.Status != enums.RunStatusRunning,
+	}
+
+	// the runs without data file have a single iteration without data
+	rows := job.data
+	if len(rows) == 0 {
+		rows = []map[string]string{nil}
+	}
+
+	iterations := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < job.run.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for iteration := range iterations {
+				s.executeIteration(job, state, iteration, rows[iteration])
+			}
+		}()
+	}
+
+	for iteration := range rows {
+		if state.isStopped() || ctx.Err() != nil {
+			break
+		}
+
+		iterations <- iteration
+	}
+
+	close(iterations)
+	wg.Wait()
+
+	if state.err != nil {
+		s.failRun(job, state.err)
+		return
+	}
+
+	// finish the run, the runs cancelled by the users keep their status
+	status := enums.RunStatusPassed
+	if ctx.Err() != nil {
+		status = enums.RunStatusCancelled
+	} else if state.failed {
+		status = enums.RunStatusFailed
+	}
+
+	_, err = s.Store.PatchRun(storeCtx, job.run.ID, func(run *store.Run) {
+		if !enums.IsFinishedRunStatus(run.Status) {
+			run.Status = status
+			run.Finished = store.NewEvent(userID)
+		}
+	})
+	if err != nil {
+		s.Logger.Error("Could not finish run", logger.Field{Key: "run", Val: job.run.ID}, logger.Field{Key: "err", Val: err})
+	}
+}
+
+// executeIteration executes the requests of a run in order with the data of an iteration
+func (s *Service) executeIteration(job *runJob, state *runState, iteration int, data map[string]string) {
+	ctx := job.ctx
+	storeCtx := detachedContext{ctx}
+	userID := httputils.GetContextAuthData(ctx).UserID
+	shared := job.run.Concurrency <= 1
+
+	// the iterations that run at the same time start with the environment of the run
+	environment := job.environment
+
+	for i, step := range job.steps {
+		if state.isStopped() || ctx.Err() != nil {
+			return
+		}
+
+		if shared {
+			state.mu.Lock()
+			environment = state.environment
+			state.mu.Unlock()
+		}
+
+		result, err := s.Executor.Run(ctx, &executor.Execution{
+			Request:     step.Request,
+			Folders:     step.Folders,
+			Project:     job.project,
+			Environment: environment,
+			Data:        data,
+			GetBlob:     s.getProjectBlob(ctx, job.run.ProjectID),
+		})
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			state.stop(err)
+			return
+		}
+
+		// save the changes of the environment, the run without environment and the iterations
+		// that run at the same time keep them in memory
+		if len(result.EnvironmentChanges) > 0 {
+			if shared {
+				if err := s.saveRunEnvironment(storeCtx, userID, state, result.EnvironmentChanges); err != nil {
+					state.stop(err)
+					return
+				}
+			} else {
+				environment = withEnvironmentChanges(environment, result.EnvironmentChanges)
+			}
+		}
+
+		// save the progress, the run could have been cancelled from another instance
+		runResult := newRunResult(step.Request, result)
+		runResult.Iteration = iteration + 1
+		runResult.Step = i + 1
+
+		run, err := s.Store.AddRunResult(storeCtx, job.run.ID, runResult)
+		if err != nil {
+			state.stop(fmt.Errorf("could not save run: %v", err))
+			return
+		}
+
+		if run.Status != enums.RunStatusRunning {
+			state.stop(nil)
+			return
+		}
+
+		if !runResult.Passed {
+			state.mu.Lock()
+			state.failed = true
+			state.mu.Unlock()
+
+			if job.run.StopOnFailure {
+				state.stop(nil)
+				return
+			}
+		}
+	}
+}
+
+// saveRunEnvironment saves the changes of the environment made by a request of a run
+func (s *Service) saveRunEnvironment(ctx context.Context, userID string, state *runState, changes map[string]*string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.environment != nil && state.environment.ID != "" {
+		environment, err := s.saveEnvironmentChanges(ctx, userID, state.environment, changes)
+		if err != nil {
+			return err
+		}
+
+		state.environment = environment
+		return nil
+	}
+
+	state.environment = withEnvironmentChanges(state.environment, changes)
+
+	return nil
+}
+
+// withEnvironmentChanges returns a copy of an environment with the changes of a request of a run,
+// the copy of a stored environment keeps its id but the changes are not saved
+func withEnvironmentChanges(environment *store.Environment, changes map[string]*string) *store.Environment {
+	copy := &store.Environment{}
+	if environment != nil {
+		*copy = *environment
+	}
+
+	copy.Variables = mergeStringMap(copy.Variables, changes)
+
+	return copy
+}
+
+// failRun finishes a run stopped by an internal error
+func (s *Service) failRun(job *runJob, err error) {
+	userID := httputils.GetContextAuthData(job.ctx).UserID
+
+	msg := err.Error()
+	if e, ok := err.(errors.InternalServer); ok {
+		msg = e.Msg
+	}
+
+	s.Logger.Error("Run failed", logger.Field{Key: "run", Val: job.run.ID}, logger.Field{Key: "err", Val: err})
+
+	_, err = s.Store.PatchRun(detachedContext{job.ctx}, job.run.ID, func(run *store.Run) {
+		if !enums.IsFinishedRunStatus(run.Status) {
+			run.Status = enums.RunStatusError
+			run.Error = msg
+			run.Finished = store.NewEvent(userID)
+		}
+	})
+	if err != nil {
+		s.Logger.Error("Could not finish run", logger.Field{Key: "run", Val: job.run.ID}, logger.Field{Key: "err", Val: err})
+	}
+}
+
+// newRunResult returns the result of a request executed by a run
+func newRunResult(request *store.Request, result *executor.Result) *store.RunResult {
+	runResult := &store.RunResult{
+		RequestID: request.ID,
+		Name:      request.Name,
+		Passed:    result.Passed,
+		Error:     result.Error,
+		Checks:    []*store.RunCheck{},
+	}
+
+	if result.Response != nil {
+		runResult.StatusCode = result.Response.StatusCode
+		runResult.Time = result.Response.Time
+	}
+
+	for _, assertion := range result.Assertions {
+		runResult.Checks = append(runResult.Checks, &store.RunCheck{
+			Type:    enums.RunCheckTypeAssertion,
+			Name:    assertion.Assertion.Type,
+			Passed:  assertion.Passed,
+			Message: assertion.Message,
+		})
+	}
+
+	for _, capture := range result.Captures {
+		runResult.Checks = append(runResult.Checks, &store.RunCheck{
+			Type:    enums.RunCheckTypeCapture,
+			Name:    capture.Capture.Variable,
+			Passed:  capture.Captured,
+			Message: capture.Message,
+		})
+	}
+
+	for _, test := range result.Tests {
+		runResult.Checks = append(runResult.Checks, &store.RunCheck{
+			Type:    enums.RunCheckTypeTest,
+			Name:    test.Name,
+			Passed:  test.Passed,
+			Message: test.Message,
+		})
+	}
+
+	return runResult
+}
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"apiboy/backend/src/config"
+	"apiboy/backend/src/executor"
 	"apiboy/backend/src/firebase"
 	"apiboy/backend/src/logger"
+	"apiboy/backend/src/scripts"
 	"apiboy/backend/src/store"
 
 	"firebase.google.com/go/auth"
@@ -19,6 +23,8 @@ type Service struct {
 	Logger             *logger.Logger
 	Store              *store.Store
 	FirebaseAuthClient *auth.Client
+	Executor           *executor.Executor
+	runs               *runQueue
 }
 
 // New returns a new Service
@@ -43,12 +49,27 @@ func New(conf *config.Config) (*Service, error) {
 	log := logger.New(conf)
 	st := store.New(conf, firestoreClient)
 
-	return &Service{
+	// the requests can't reach the internal addresses, except the allowed networks
+	allowedNetworks, err := executor.ParseNetworks(conf.AllowedNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ALLOWED_NETWORKS: %v", err)
+	}
+
+	httpClient := executor.NewClient(time.Duration(conf.ExecutionTimeout)*time.Second, allowedNetworks)
+	sandbox := scripts.New(time.Duration(conf.ScriptTimeout)*time.Millisecond, conf.ScriptMaxMemory, conf.ScriptConcurrency)
+
+	s := &Service{
 		Config:             conf,
 		Logger:             log,
 		Store:              st,
 		FirebaseAuthClient: firebaseAuthClient,
-	}, nil
+		Executor:           executor.New(httpClient, conf.MaxResponseSize, sandbox),
+		runs:               newRunQueue(),
+	}
+
+	s.startRunWorkers(conf.RunWorkers)
+
+	return s, nil
 }
 
 // Run executes the service
@@ -2,12 +2,43 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"apiboy/backend/src/enums"
 	"apiboy/backend/src/errors"
+	"apiboy/backend/src/executor"
+	"apiboy/backend/src/exporters"
+	"apiboy/backend/src/importers"
+	"apiboy/backend/src/logger"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/scripts"
 	"apiboy/backend/src/store"
 )
 
+// revisionIgnoredFields are the fields that are not compared between revisions
+var revisionIgnoredFields = []string{"created", "updated", "deleted"}
+
+// mergeStringMap applies a JSON merge patch to a map of strings,
+// the keys with a nil value are removed and the others are added or replaced
+func mergeStringMap(m map[string]string, patch map[string]*string) map[string]string {
+	merged := map[string]string{}
+
+	for key, value := range m {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+		} else {
+			merged[key] = *value
+		}
+	}
+
+	return merged
+}
+
 // checkAccessToProject validates if a user has access to a project
 func (s *Service) checkAccessToProject(ctx context.Context, userID, projectID string) error {
 	// check if a relationship between the project and the user exists
@@ -21,6 +52,313 @@ func (s *Service) checkAccessToProject(ctx context.Context, userID, projectID st
 	return nil
 }
 
+// checkRequestBody validates the parts of the body of a request that are not checked by the input validations,
+// the files must be blobs of the project of the request and the GraphQL variables must be a JSON object
+func (s *Service) checkRequestBody(ctx context.Context, projectID string, parts []*store.MultipartPart, binaryBlobID string, graphQL *store.GraphQLBody) error {
+	blobIDs := []string{}
+
+	for _, part := range parts {
+		if part == nil {
+			return errors.BadRequest{Msg: "Invalid multipart part"}
+		}
+
+		if part.Type == enums.MultipartPartTypeFile {
+			blobIDs = append(blobIDs, part.BlobID)
+		} else if part.Type != enums.MultipartPartTypeText {
+			return errors.BadRequest{Msg: "Invalid multipart part type"}
+		}
+	}
+
+	if binaryBlobID != "" {
+		blobIDs = append(blobIDs, binaryBlobID)
+	}
+
+	for _, blobID := range blobIDs {
+		blob, err := s.Store.GetBlobByID(ctx, blobID)
+		if err != nil {
+			return errors.InternalServer{Msg: "Could not get blob", Err: err}
+		} else if blob == nil || blob.ProjectID != projectID {
+			return errors.NotFound{Obj: "Blob"}
+		}
+	}
+
+	if graphQL != nil && strings.TrimSpace(graphQL.Variables) != "" {
+		variables := map[string]interface{}{}
+
+		if err := json.Unmarshal([]byte(graphQL.Variables), &variables); err != nil {
+			return errors.BadRequest{Msg: "Invalid GraphQL variables"}
+		}
+	}
+
+	return nil
+}
+
+// copyRequestBlobs copies the files used in the body of the requests to a project, and replaces the
+// references of the requests with the copies. It returns the ids of the copies, so they can be deleted
+// if the requests are not saved. If a copy fails, the previous copies are deleted.
+func (s *Service) copyRequestBlobs(ctx context.Context, userID, projectID string, requests []*store.Request) ([]string, error) {
+	copies := map[string]string{}
+	copied := []string{}
+
+	copyBlob := func(id string) (string, error) {
+		if copyID, ok := copies[id]; ok || id == "" {
+			return copyID, nil
+		}
+
+		blob, err := s.Store.GetBlobByID(ctx, id)
+		if err != nil {
+			return "", errors.InternalServer{Msg: "Could not get blob", Err: err}
+		} else if blob == nil || blob.ProjectID == projectID {
+			copies[id] = id
+			return id, nil
+		}
+
+		blob.ID = s.Store.NewBlobID()
+		blob.ProjectID = projectID
+
+		if err := s.Store.CreateBlob(ctx, userID, blob); err != nil {
+			return "", errors.InternalServer{Msg: "Could not copy blob", Err: err}
+		}
+
+		copies[id] = blob.ID
+		copied = append(copied, blob.ID)
+		return blob.ID, nil
+	}
+
+	err := func() (err error) {
+		for _, request := range requests {
+			for _, part := range request.MultipartParts {
+				if part != nil && part.Type == enums.MultipartPartTypeFile {
+					if part.BlobID, err = copyBlob(part.BlobID); err != nil {
+						return err
+					}
+				}
+			}
+
+			if request.BinaryBlobID, err = copyBlob(request.BinaryBlobID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		s.deleteBlobs(ctx, copied)
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// deleteBlobs deletes the blobs copied for documents that could not be saved, the errors are logged
+func (s *Service) deleteBlobs(ctx context.Context, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := s.Store.DeleteBlobs(ctx, ids); err != nil {
+		s.Logger.Error("Could not delete copied blobs", logger.Field{Key: "blobs", Val: ids}, logger.Field{Key: "err", Val: err})
+	}
+}
+
+// checkAuth validates the settings of an auth that are not checked by the input validations,
+// the projects do not have a parent to inherit the auth from
+func checkAuth(auth *store.Auth, canInherit bool) error {
+	if auth == nil {
+		return nil
+	}
+
+	if !enums.IsValidAuthType(auth.Type) || (auth.Type == enums.AuthTypeInherit && !canInherit) {
+		return errors.BadRequest{Msg: "Invalid auth type"}
+	}
+
+	if auth.Type == enums.AuthTypeAPIKey && auth.APIKey != nil &&
+		auth.APIKey.In != enums.APIKeyInHeader && auth.APIKey.In != enums.APIKeyInQuery {
+		return errors.BadRequest{Msg: "Invalid API key location"}
+	}
+
+	if auth.Type == enums.AuthTypeOAuth2 && auth.OAuth2 != nil &&
+		auth.OAuth2.GrantType != enums.OAuth2GrantClientCredentials && auth.OAuth2.GrantType != enums.OAuth2GrantPassword {
+		return errors.BadRequest{Msg: "Invalid OAuth2 grant type"}
+	}
+
+	return nil
+}
+
+// getProjectBlob returns a function to get the blobs of a project, the blobs of other projects are not found
+func (s *Service) getProjectBlob(ctx context.Context, projectID string) requestutils.BlobGetter {
+	return func(id string) (*store.Blob, error) {
+		blob, err := s.Store.GetBlobByID(ctx, id)
+		if err != nil {
+			return nil, errors.InternalServer{Msg: "Could not get blob", Err: err}
+		} else if blob == nil || blob.ProjectID != projectID {
+			return nil, nil
+		}
+
+		return blob, nil
+	}
+}
+
+// checkParams validates a list of params, like the headers or the query params
+func checkParams(params []*store.Param, name string) error {
+	for _, param := range params {
+		if param == nil {
+			return errors.BadRequest{Msg: "Invalid " + name}
+		}
+	}
+
+	return nil
+}
+
+// checkAssertions validates the settings of the assertions of a request
+func checkAssertions(assertions []*store.Assertion) error {
+	for _, assertion := range assertions {
+		if assertion == nil {
+			return errors.BadRequest{Msg: "Invalid assertion"}
+		}
+
+		if err := executor.CheckAssertion(assertion); err != nil {
+			return errors.BadRequest{Msg: "Invalid assertion: " + err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// checkCaptures validates the settings of the captures of a request
+func checkCaptures(captures []*store.Capture) error {
+	for _, capture := range captures {
+		if capture == nil {
+			return errors.BadRequest{Msg: "Invalid capture"}
+		}
+
+		if err := executor.CheckCapture(capture); err != nil {
+			return errors.BadRequest{Msg: "Invalid capture: " + err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// saveEnvironmentChanges saves the variables of an environment set or unset (nil) by the scripts and
+// the captures of an execution. The update is recorded in the revisions and the audit log with the user.
+func (s *Service) saveEnvironmentChanges(ctx context.Context, userID string, environment *store.Environment, changes map[string]*string) (*store.Environment, error) {
+	updated, err := s.Store.PatchEnvironment(ctx, userID, environment.ID, 0, func(environment *store.Environment) {
+		environment.Variables = mergeStringMap(environment.Variables, changes)
+	})
+	if err != nil {
+		switch err.(type) {
+		case errors.NotFound:
+			return nil, err
+		}
+
+		return nil, errors.InternalServer{Msg: "Could not update environment", Err: err}
+	}
+
+	if err := s.recordAuditEntry(ctx, enums.AuditActionUpdateEnvironment, enums.EntityTypeEnvironment, environment, updated); err != nil {
+		s.Logger.Error("Could not create audit entry",
+			logger.Field{Key: "action", Val: enums.AuditActionUpdateEnvironment},
+			logger.Field{Key: "err", Val: err},
+		)
+	}
+
+	return updated, nil
+}
+
+// checkScripts checks the syntax of the pre-request and test scripts of a request or folder
+func checkScripts(preRequestScript, testScript *string) error {
+	if preRequestScript != nil {
+		if err := scripts.Check(*preRequestScript); err != nil {
+			return errors.BadRequest{Msg: "Invalid pre-request script: " + err.Error()}
+		}
+	}
+
+	if testScript != nil {
+		if err := scripts.Check(*testScript); err != nil {
+			return errors.BadRequest{Msg: "Invalid test script: " + err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// getRequestParents returns the folders of a request, from the nearest to the farthest, and its project
+func (s *Service) getRequestParents(ctx context.Context, request *store.Request) ([]*store.Folder, *store.Project, error) {
+	folder, err := s.Store.GetFolderByID(ctx, request.FolderID)
+	if err != nil {
+		return nil, nil, errors.InternalServer{Msg: "Could not get folder", Err: err}
+	} else if folder == nil {
+		return nil, nil, errors.NotFound{Obj: "Folder"}
+	}
+
+	folders, err := s.getFolderChain(ctx, folder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := s.Store.GetProjectByID(ctx, request.ProjectID)
+	if err != nil {
+		return nil, nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, nil, errors.NotFound{Obj: "Project"}
+	}
+
+	return folders, project, nil
+}
+
+// createImportedProject creates the project converted by an importer, the project belongs to the user
+func (s *Service) createImportedProject(ctx context.Context, userID string, result *importers.Result) error {
+	projectUser := &store.ProjectUser{
+		ID:        s.Store.NewProjectUserID(result.Project.ID, userID),
+		ProjectID: result.Project.ID,
+		UserID:    userID,
+	}
+
+	if err := s.Store.CreateProjectTree(ctx, userID, result.Project, projectUser, result.Folders, result.Requests, result.Environments); err != nil {
+		return errors.InternalServer{Msg: "Could not create imported project", Err: err}
+	}
+
+	return nil
+}
+
+// getExportedProject gets a project with its folders, requests, environments and saved responses for an exporter
+func (s *Service) getExportedProject(ctx context.Context, projectID string) (*exporters.Project, error) {
+	project, err := s.Store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get project", Err: err}
+	} else if project == nil {
+		return nil, errors.NotFound{Obj: "Project"}
+	}
+
+	folders, err := s.Store.GetFoldersByProjectID(ctx, projectID, false)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get folders", Err: err}
+	}
+
+	requests, err := s.Store.GetRequestsByProjectID(ctx, projectID, false)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get requests", Err: err}
+	}
+
+	environments, err := s.Store.GetEnvironmentsByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get environments", Err: err}
+	}
+
+	responses, err := s.Store.GetResponsesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, errors.InternalServer{Msg: "Could not get responses", Err: err}
+	}
+
+	return &exporters.Project{
+		Project:      project,
+		Folders:      folders,
+		Requests:     requests,
+		Environments: environments,
+		Responses:    responses,
+	}, nil
+}
+
 // createExampleProject creates an example project for the given user
 func (s *Service) createExampleProject(ctx context.Context, userID string) error {
 	// create project
@@ -63,13 +401,15 @@ func (s *Service) createExampleProject(ctx context.Context, userID string) error
 		ProjectID: project.ID,
 		Type:      enums.RequestTypePost,
 		URL:       "https://httpbin.org/anything",
-		Headers: map[string]string{
-			"Accept": "application/json",
+		BodyMode:  enums.BodyModeRaw,
+		Headers: []*store.Param{
+			&store.Param{Key: "Accept", Value: "application/json", Enabled: true},
 		},
 		Body: `{
   "key1": "value1",
   "key2": "value2"
 }`,
+		BodyContentType: "application/json",
 	}
 
 	if err := s.Store.CreateRequest(ctx, userID, request); err != nil {
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+	"apiboy/backend/src/store"
+)
+
+func TestCheckAssertionsRejectsRemoteSchemaRefs(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"type":"object"}`))
+	}))
+	defer server.Close()
+
+	schemas := []string{
+		`{"$ref":"` + server.URL + `/schema.json"}`,
+		`{"properties":{"id":{"$ref":"` + server.URL + `/schema.json#/definitions/id"}}}`,
+		`{"$ref":"file:///etc/passwd"}`,
+		`{"items":[{"$ref":"other.json"}]}`,
+	}
+
+	for _, schema := range schemas {
+		err := checkAssertions([]*store.Assertion{{Type: enums.AssertionTypeJSONSchema, Value: schema, Enabled: true}})
+		if _, ok := err.(errors.BadRequest); !ok {
+			t.Errorf("schema %s: expected a bad request error, got %v", schema, err)
+		}
+	}
+
+	if requests > 0 {
+		t.Errorf("expected no requests to the schema server, got %d", requests)
+	}
+
+	local := `{"definitions":{"id":{"type":"integer"}},"properties":{"id":{"$ref":"#/definitions/id"}}}`
+	if err := checkAssertions([]*store.Assertion{{Type: enums.AssertionTypeJSONSchema, Value: local, Enabled: true}}); err != nil {
+		t.Errorf("expected a local reference to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package snippets
+
+import (
+	"strings"
+)
+
+// curl renders the request as a curl command line
+func (s *snippet) curl() string {
+	r := s.request
+	lines := []string{"curl --request " + r.Method, "--url " + shellQuote(r.URL)}
+
+	for _, header := range r.Headers {
+		lines = append(lines, "--header "+shellQuote(header.Key+": "+header.Value))
+	}
+
+	if s.basic != nil {
+		lines = append(lines, "--user "+shellQuote(s.basic.Username+":"+s.basic.Password))
+	}
+
+	if s.digest != nil {
+		lines = append(lines, "--digest", "--user "+shellQuote(s.digest.Username+":"+s.digest.Password))
+	}
+
+	for _, field := range r.Form {
+		value := field.Value
+		if field.FileName != "" {
+			value = "@" + field.FileName
+		}
+		if field.ContentType != "" {
+			value += ";type=" + field.ContentType
+		}
+
+		// the values starting with @ or < are read from files by curl, so the text values are sent with --form-string
+		option := "--form "
+		if field.FileName == "" && field.ContentType == "" {
+			option = "--form-string "
+		}
+
+		lines = append(lines, option+shellQuote(field.Key+"="+value))
+	}
+
+	if r.File != "" {
+		lines = append(lines, "--data-binary "+shellQuote("@"+r.File))
+	} else if r.Body != "" {
+		lines = append(lines, "--data-raw "+shellQuote(r.Body))
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
@@ -0,0 +1,122 @@
+package snippets
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// golang renders the request as a Go program using net/http
+func (s *snippet) golang() string {
+	if s.digest != nil {
+		s.warn("The Digest auth is not supported by net/http, it was not included")
+	}
+
+	r := s.request
+	imports := map[string]bool{"fmt": true, "io/ioutil": true, "net/http": true}
+	code := &strings.Builder{}
+	body := "nil"
+
+	switch {
+	case len(r.Form) > 0:
+		imports["bytes"] = true
+		imports["mime/multipart"] = true
+		body = "payload"
+
+		code.WriteString("\tpayload := &bytes.Buffer{}\n")
+		code.WriteString("\twriter := multipart.NewWriter(payload)\n")
+
+		files := 0
+		for _, field := range r.Form {
+			if field.FileName == "" {
+				fmt.Fprintf(code, "\twriter.WriteField(%s, %s)\n", goString(field.Key), goString(field.Value))
+				continue
+			}
+
+			imports["io"] = true
+			imports["os"] = true
+			files++
+
+			fmt.Fprintf(code, "\n\tfile%d, err := os.Open(%s)\n", files, goString(field.FileName))
+			code.WriteString(goPanic)
+			fmt.Fprintf(code, "\tdefer file%d.Close()\n\n", files)
+			fmt.Fprintf(code, "\tpart%d, err := writer.CreateFormFile(%s, %s)\n", files, goString(field.Key), goString(field.FileName))
+			code.WriteString(goPanic)
+			fmt.Fprintf(code, "\tif _, err := io.Copy(part%d, file%d); err != nil {\n\t\tpanic(err)\n\t}\n\n", files, files)
+		}
+
+		code.WriteString("\twriter.Close()\n\n")
+
+	case r.File != "":
+		imports["os"] = true
+		body = "payload"
+
+		fmt.Fprintf(code, "\tpayload, err := os.Open(%s)\n", goString(r.File))
+		code.WriteString(goPanic)
+		code.WriteString("\tdefer payload.Close()\n\n")
+
+	case r.Body != "":
+		imports["strings"] = true
+		body = "payload"
+
+		fmt.Fprintf(code, "\tpayload := strings.NewReader(%s)\n\n", goString(r.Body))
+	}
+
+	fmt.Fprintf(code, "\treq, err := http.NewRequest(%s, %s, %s)\n", goString(r.Method), goString(r.URL), body)
+	code.WriteString(goPanic)
+	code.WriteString("\n")
+
+	for _, header := range r.Headers {
+		fmt.Fprintf(code, "\treq.Header.Add(%s, %s)\n", goString(header.Key), goString(header.Value))
+	}
+
+	if len(r.Form) > 0 {
+		code.WriteString("\treq.Header.Set(\"Content-Type\", writer.FormDataContentType())\n")
+	}
+
+	if s.basic != nil {
+		fmt.Fprintf(code, "\treq.SetBasicAuth(%s, %s)\n", goString(s.basic.Username), goString(s.basic.Password))
+	}
+
+	if len(r.Headers) > 0 || len(r.Form) > 0 || s.basic != nil {
+		code.WriteString("\n")
+	}
+
+	code.WriteString("\tres, err := http.DefaultClient.Do(req)\n")
+	code.WriteString(goPanic)
+	code.WriteString("\tdefer res.Body.Close()\n\n")
+	code.WriteString("\tdata, err := ioutil.ReadAll(res.Body)\n")
+	code.WriteString(goPanic)
+	code.WriteString("\n\tfmt.Println(res.Status)\n")
+	code.WriteString("\tfmt.Println(string(data))\n")
+
+	packages := []string{}
+	for name := range imports {
+		packages = append(packages, name)
+	}
+	sort.Strings(packages)
+
+	program := &strings.Builder{}
+	program.WriteString("package main\n\nimport (\n")
+	for _, name := range packages {
+		fmt.Fprintf(program, "\t%q\n", name)
+	}
+	program.WriteString(")\n\nfunc main() {\n")
+	program.WriteString(code.String())
+	program.WriteString("}\n")
+
+	return program.String()
+}
+
+// goPanic is the error handling of the Go snippets
+const goPanic = "\tif err != nil {\n\t\tpanic(err)\n\t}\n"
+
+// goString returns a Go string literal, the multiline values are raw strings when possible
+func goString(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
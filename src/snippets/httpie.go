@@ -0,0 +1,58 @@
+package snippets
+
+import (
+	"strings"
+)
+
+// httpieSeparators are the characters of the request items of HTTPie that must be escaped in the keys
+var httpieSeparators = strings.NewReplacer(`\`, `\\`, ":", `\:`, "=", `\=`, "@", `\@`)
+
+// httpie renders the request as an HTTPie command line
+func (s *snippet) httpie() string {
+	r := s.request
+	command := "http"
+	lines := []string{}
+
+	if s.basic != nil {
+		lines = append(lines, "--auth "+shellQuote(s.basic.Username+":"+s.basic.Password))
+	}
+
+	if s.digest != nil {
+		lines = append(lines, "--auth-type digest", "--auth "+shellQuote(s.digest.Username+":"+s.digest.Password))
+	}
+
+	if len(r.Form) > 0 {
+		lines = append(lines, "--multipart")
+	}
+
+	lines = append(lines, r.Method+" "+shellQuote(r.URL))
+
+	for _, header := range r.Headers {
+		lines = append(lines, shellQuote(httpieSeparators.Replace(header.Key)+":"+header.Value))
+	}
+
+	for _, field := range r.Form {
+		item := httpieSeparators.Replace(field.Key) + "=" + field.Value
+		if strings.HasPrefix(field.Value, "@") {
+			// the =@ separator reads the value from a file
+			item = httpieSeparators.Replace(field.Key) + `=\` + field.Value
+		}
+		if field.FileName != "" {
+			item = httpieSeparators.Replace(field.Key) + "@" + field.FileName
+			if field.ContentType != "" {
+				item += ";type=" + field.ContentType
+			}
+		}
+
+		lines = append(lines, shellQuote(item))
+	}
+
+	// the body is sent through the standard input
+	if r.File != "" {
+		lines = append(lines, "< "+shellQuote(r.File))
+	} else if r.Body != "" && len(r.Form) == 0 {
+		command = "printf '%s' " + shellQuote(r.Body) + " | http"
+	}
+
+	return command + " " + strings.Join(lines, " \\\n  ")
+}
@@ -0,0 +1,76 @@
+package snippets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// javascript renders the request as JavaScript code using fetch
+func (s *snippet) javascript() string {
+	if s.digest != nil {
+		s.warn("The Digest auth is not supported by fetch, it was not included")
+	}
+
+	s.basicAuthHeader()
+
+	r := s.request
+	code := &strings.Builder{}
+	body := ""
+
+	switch {
+	case len(r.Form) > 0:
+		body = "form"
+
+		code.WriteString("const form = new FormData();\n")
+		for _, field := range r.Form {
+			if field.FileName == "" {
+				fmt.Fprintf(code, "form.append(%s, %s);\n", jsonString(field.Key), jsonString(field.Value))
+				continue
+			}
+
+			s.warn("The content of the file %s must be added to the form field %q", field.FileName, field.Key)
+			fmt.Fprintf(code, "form.append(%s, new File([], %s));\n", jsonString(field.Key), jsonString(field.FileName))
+		}
+		code.WriteString("\n")
+
+	case r.File != "":
+		body = fmt.Sprintf("new File([], %s)", jsonString(r.File))
+		s.warn("The content of the file %s must be added to the body", r.File)
+
+	case r.Body != "":
+		body = jsonString(r.Body)
+	}
+
+	fmt.Fprintf(code, "const response = await fetch(%s, {\n", jsonString(r.URL))
+	fmt.Fprintf(code, "  method: %s,\n", jsonString(r.Method))
+
+	if len(r.Headers) > 0 {
+		code.WriteString("  headers: {\n")
+		for _, header := range r.Headers {
+			fmt.Fprintf(code, "    %s: %s,\n", jsonString(header.Key), jsonString(header.Value))
+		}
+		code.WriteString("  },\n")
+	}
+
+	if body != "" {
+		fmt.Fprintf(code, "  body: %s,\n", body)
+	}
+
+	code.WriteString("});\n\n")
+	code.WriteString("console.log(response.status);\n")
+	code.WriteString("console.log(await response.text());\n")
+
+	return code.String()
+}
+
+// jsonString returns a JSON string, without escaping the HTML characters
+func jsonString(s string) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
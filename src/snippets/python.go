@@ -0,0 +1,70 @@
+package snippets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// python renders the request as a Python script using requests
+func (s *snippet) python() string {
+	r := s.request
+	imports := []string{"import requests"}
+	code := &strings.Builder{}
+	args := []string{pythonString(r.Method), "url"}
+
+	fmt.Fprintf(code, "url = %s\n", pythonString(r.URL))
+
+	if len(r.Headers) > 0 {
+		code.WriteString("\nheaders = {\n")
+		for _, header := range r.Headers {
+			fmt.Fprintf(code, "    %s: %s,\n", pythonString(header.Key), pythonString(header.Value))
+		}
+		code.WriteString("}\n")
+
+		args = append(args, "headers=headers")
+	}
+
+	switch {
+	case len(r.Form) > 0:
+		code.WriteString("\nfiles = {\n")
+		for _, field := range r.Form {
+			value := fmt.Sprintf("(None, %s)", pythonString(field.Value))
+			if field.FileName != "" {
+				value = fmt.Sprintf("open(%s, \"rb\")", pythonString(field.FileName))
+			}
+
+			fmt.Fprintf(code, "    %s: %s,\n", pythonString(field.Key), value)
+		}
+		code.WriteString("}\n")
+
+		args = append(args, "files=files")
+
+	case r.File != "":
+		fmt.Fprintf(code, "\npayload = open(%s, \"rb\")\n", pythonString(r.File))
+		args = append(args, "data=payload")
+
+	case r.Body != "":
+		fmt.Fprintf(code, "\npayload = %s\n", pythonString(r.Body))
+		args = append(args, "data=payload.encode(\"utf-8\")")
+	}
+
+	if s.basic != nil {
+		args = append(args, fmt.Sprintf("auth=(%s, %s)", pythonString(s.basic.Username), pythonString(s.basic.Password)))
+	}
+
+	if s.digest != nil {
+		imports = append(imports, "from requests.auth import HTTPDigestAuth")
+		args = append(args, fmt.Sprintf("auth=HTTPDigestAuth(%s, %s)", pythonString(s.digest.Username), pythonString(s.digest.Password)))
+	}
+
+	fmt.Fprintf(code, "\nresponse = requests.request(%s)\n\n", strings.Join(args, ", "))
+	code.WriteString("print(response.status_code)\n")
+	code.WriteString("print(response.text)\n")
+
+	return strings.Join(imports, "\n") + "\n\n" + code.String()
+}
+
+// pythonString returns a Python string literal, the JSON strings are valid Python strings
+func pythonString(s string) string {
+	return jsonString(s)
+}
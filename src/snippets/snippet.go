@@ -0,0 +1,273 @@
+package snippets
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/requestutils"
+	"apiboy/backend/src/store"
+)
+
+// Request is a request with its references to variables replaced, ready to be rendered as code
+type Request struct {
+	Method  string
+	URL     string
+	Headers []*Header
+	Body    string
+	Form    []*FormField
+	File    string
+	Auth    *store.Auth
+}
+
+// Header is a header of a request
+type Header struct {
+	Key   string
+	Value string
+}
+
+// FormField is a field of a multipart body, the fields with a file name send the content of that file
+type FormField struct {
+	Key         string
+	Value       string
+	FileName    string
+	ContentType string
+}
+
+// Snippet is the code of a request in a language, with warnings about the settings that could not be rendered
+type Snippet struct {
+	Code     string
+	Warnings []string
+}
+
+// snippet is the state of a snippet while it is rendered
+type snippet struct {
+	request  *Request
+	basic    *store.BasicAuth
+	digest   *store.DigestAuth
+	warnings []string
+}
+
+// NewRequest returns the request to render from a resolved request and its body. The references to
+// variables of the body are replaced with the variables of the resolved request, and the files of
+// the body are only referenced by their names.
+func NewRequest(resolved *requestutils.ResolvedRequest, request *store.Request, getBlob requestutils.BlobGetter) (*Request, error) {
+	r := &Request{
+		Method:  resolved.Method,
+		URL:     resolved.URL,
+		Headers: []*Header{},
+		Form:    []*FormField{},
+		Auth:    resolved.Auth,
+	}
+
+	for _, header := range resolved.Headers {
+		r.Headers = append(r.Headers, &Header{Key: header.Key, Value: header.Value})
+	}
+
+	body := requestutils.WithBodyVariables(request, resolved.VariableValues())
+	contentType := ""
+
+	switch body.BodyMode {
+	case enums.BodyModeNone:
+
+	case enums.BodyModeFormData:
+		for _, part := range body.MultipartParts {
+			if !part.Enabled || part.Key == "" {
+				continue
+			}
+
+			field := &FormField{Key: part.Key, Value: part.Value, ContentType: part.ContentType}
+
+			if part.Type == enums.MultipartPartTypeFile {
+				blob, err := getBlob(part.BlobID)
+				if err != nil {
+					return nil, err
+				}
+
+				field.Value = ""
+				field.FileName = part.Key
+				if blob != nil && blob.FileName != "" {
+					field.FileName = blob.FileName
+				}
+			}
+
+			r.Form = append(r.Form, field)
+		}
+
+	case enums.BodyModeBinary:
+		blob, err := getBlob(body.BinaryBlobID)
+		if err != nil {
+			return nil, err
+		}
+
+		r.File = "file"
+		contentType = "application/octet-stream"
+
+		if blob != nil {
+			if blob.FileName != "" {
+				r.File = blob.FileName
+			}
+			if blob.ContentType != "" {
+				contentType = blob.ContentType
+			}
+		}
+
+	default:
+		// the raw, urlencoded and GraphQL bodies do not need files
+		data, bodyContentType, err := requestutils.BuildBody(body, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Body = string(data)
+		contentType = bodyContentType
+	}
+
+	// the content type of the body is sent in a header, unless the request already has one
+	if contentType != "" && r.header("Content-Type") == nil {
+		r.Headers = append(r.Headers, &Header{Key: "Content-Type", Value: contentType})
+	}
+
+	return r, nil
+}
+
+// Generate renders a request as code in a language
+func Generate(language string, request *Request) *Snippet {
+	s := &snippet{warnings: []string{}}
+	s.prepare(request)
+
+	code := ""
+
+	switch language {
+	case enums.SnippetLanguageCurl:
+		code = s.curl()
+	case enums.SnippetLanguageGo:
+		code = s.golang()
+	case enums.SnippetLanguagePython:
+		code = s.python()
+	case enums.SnippetLanguageJavaScript:
+		code = s.javascript()
+	case enums.SnippetLanguageHTTPie:
+		code = s.httpie()
+	}
+
+	return &Snippet{
+		Code:     code,
+		Warnings: s.warnings,
+	}
+}
+
+// prepare copies the request with its auth applied to the headers and the url, the Basic and Digest
+// auths are kept apart because every language has its own way to send them
+func (s *snippet) prepare(request *Request) {
+	r := *request
+	r.Headers = append([]*Header{}, request.Headers...)
+	s.request = &r
+
+	// the boundary of the multipart bodies is generated by the clients with their own Content-Type
+	if len(r.Form) > 0 {
+		r.removeHeader("Content-Type")
+	}
+
+	auth := request.Auth
+	if auth == nil {
+		return
+	}
+
+	switch auth.Type {
+	case enums.AuthTypeBasic:
+		if auth.Basic != nil {
+			s.basic = auth.Basic
+		}
+
+	case enums.AuthTypeDigest:
+		if auth.Digest != nil {
+			s.digest = auth.Digest
+		}
+
+	case enums.AuthTypeBearer:
+		if auth.Bearer != nil {
+			r.setHeader("Authorization", "Bearer "+auth.Bearer.Token)
+		}
+
+	case enums.AuthTypeAPIKey:
+		if auth.APIKey == nil || auth.APIKey.Key == "" {
+			return
+		}
+
+		if auth.APIKey.In == enums.APIKeyInQuery {
+			separator := "?"
+			if strings.Contains(r.URL, "?") {
+				separator = "&"
+			}
+
+			r.URL += separator + url.QueryEscape(auth.APIKey.Key) + "=" + url.QueryEscape(auth.APIKey.Value)
+		} else {
+			r.setHeader(auth.APIKey.Key, auth.APIKey.Value)
+		}
+
+	case enums.AuthTypeOAuth2:
+		r.setHeader("Authorization", "Bearer <access token>")
+		s.warn("The OAuth2 access token must be requested before sending the request, it was replaced with a placeholder")
+
+	case enums.AuthTypeAWSV4:
+		s.warn("The AWS Signature Version 4 auth is not supported, the request must be signed before it is sent")
+	}
+}
+
+// basicAuthHeader adds the Basic auth to the headers, for the languages without native support
+func (s *snippet) basicAuthHeader() {
+	if s.basic == nil {
+		return
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(s.basic.Username + ":" + s.basic.Password))
+	s.request.setHeader("Authorization", "Basic "+credentials)
+	s.basic = nil
+}
+
+// warn adds a warning to the snippet
+func (s *snippet) warn(format string, args ...interface{}) {
+	s.warnings = append(s.warnings, fmt.Sprintf(format, args...))
+}
+
+// header returns a header of the request, or nil if it is not included
+func (r *Request) header(key string) *Header {
+	for _, header := range r.Headers {
+		if strings.EqualFold(header.Key, key) {
+			return header
+		}
+	}
+
+	return nil
+}
+
+// setHeader sets the value of a header, replacing the headers with the same key
+func (r *Request) setHeader(key, value string) {
+	r.removeHeader(key)
+	r.Headers = append(r.Headers, &Header{Key: key, Value: value})
+}
+
+// removeHeader removes the headers with a key
+func (r *Request) removeHeader(key string) {
+	headers := []*Header{}
+
+	for _, header := range r.Headers {
+		if !strings.EqualFold(header.Key, key) {
+			headers = append(headers, header)
+		}
+	}
+
+	r.Headers = headers
+}
+
+// shellQuote quotes a value for a POSIX shell
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@,") == "" {
+		return s
+	}
+
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,12 @@
+package store
+
+// Assertion is a declarative test of the response of a request. The property is the name of the
+// header or the JSON path checked by the assertion, and the value is the expected status code,
+// value, regular expression, maximum response time or JSON Schema according to its type.
+// The values can reference environment variables like {{name}}.
+type Assertion struct {
+	Type     string `json:"type" firestore:"type"`
+	Property string `json:"property" firestore:"property"`
+	Value    string `json:"value" firestore:"value"`
+	Enabled  bool   `json:"enabled" firestore:"enabled"`
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"apiboy/backend/src/diffutils"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+)
+
+// AuditCollection is the name of the collection
+const AuditCollection = "audit"
+
+// AuditEntry represents a model in the database, the audit entries are never updated or deleted
+type AuditEntry struct {
+	ID         string                       `json:"id" firestore:"id"`
+	Action     string                       `json:"action" firestore:"action"`
+	EntityType string                       `json:"entity_type" firestore:"entity_type"`
+	EntityID   string                       `json:"entity_id" firestore:"entity_id"`
+	ProjectID  string                       `json:"project_id" firestore:"project_id"`
+	IP         string                       `json:"ip" firestore:"ip"`
+	Changes    map[string]*diffutils.Change `json:"changes" firestore:"changes"`
+	Created    *Event                       `json:"created" firestore:"created"`
+}
+
+// AuditFilter contains the optional conditions to query audit entries
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	ProjectID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+// NewAuditEntryID generates a UUID for audit entries
+func (s *Store) NewAuditEntryID() string {
+	return "aud-" + uuid.New().String()
+}
+
+// CreateAuditEntry creates a new AuditEntry
+func (s *Store) CreateAuditEntry(ctx context.Context, userID string, entry *AuditEntry) error {
+	entry.Created = NewEvent(userID)
+	_, err := s.Client.Collection(AuditCollection).Doc(entry.ID).Set(ctx, entry)
+	return err
+}
+
+// GetAuditEntries gets a page of audit entries matching the filter, sorted from newest to oldest.
+// The cursor is the id of the last entry of the previous page, and the returned cursor
+// is empty when there are no more pages.
+func (s *Store) GetAuditEntries(ctx context.Context, filter *AuditFilter, cursor string, limit int) ([]*AuditEntry, string, error) {
+	q := s.Client.Collection(AuditCollection).OrderBy("created.at", firestore.Desc)
+
+	if filter.ActorID != "" {
+		q = q.Where("created.by", "==", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		q = q.Where("action", "==", filter.Action)
+	}
+
+	if filter.EntityType != "" {
+		q = q.Where("entity_type", "==", filter.EntityType)
+	}
+
+	if filter.EntityID != "" {
+		q = q.Where("entity_id", "==", filter.EntityID)
+	}
+
+	if filter.ProjectID != "" {
+		q = q.Where("project_id", "==", filter.ProjectID)
+	}
+
+	if filter.From != nil {
+		q = q.Where("created.at", ">=", *filter.From)
+	}
+
+	if filter.To != nil {
+		q = q.Where("created.at", "<", *filter.To)
+	}
+
+	if cursor != "" {
+		snapshot, err := s.Client.Collection(AuditCollection).Doc(cursor).Get(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		q = q.StartAfter(snapshot)
+	}
+
+	snapshots, err := q.Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries := []*AuditEntry{}
+	nextCursor := ""
+
+	for _, snapshot := range snapshots {
+		entry := &AuditEntry{}
+		snapshot.DataTo(entry)
+
+		entries = append(entries, entry)
+		nextCursor = entry.ID
+	}
+
+	if len(snapshots) < limit {
+		nextCursor = ""
+	}
+
+	return entries, nextCursor, nil
+}
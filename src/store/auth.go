@@ -0,0 +1,161 @@
+package store
+
+// Auth contains the authentication settings of a request, folder or project.
+// Only the settings of its type are used, and their values can reference
+// environment variables like {{name}}.
+type Auth struct {
+	Type   string      `json:"type" firestore:"type"`
+	Basic  *BasicAuth  `json:"basic,omitempty" firestore:"basic,omitempty"`
+	Bearer *BearerAuth `json:"bearer,omitempty" firestore:"bearer,omitempty"`
+	APIKey *APIKeyAuth `json:"apikey,omitempty" firestore:"apikey,omitempty"`
+	Digest *DigestAuth `json:"digest,omitempty" firestore:"digest,omitempty"`
+	AWSV4  *AWSV4Auth  `json:"awsv4,omitempty" firestore:"awsv4,omitempty"`
+	OAuth2 *OAuth2Auth `json:"oauth2,omitempty" firestore:"oauth2,omitempty"`
+}
+
+// BasicAuth contains the settings of the HTTP Basic auth
+type BasicAuth struct {
+	Username string `json:"username" firestore:"username"`
+	Password string `json:"password" firestore:"password"`
+}
+
+// BearerAuth contains the settings of the auth with a bearer token
+type BearerAuth struct {
+	Token string `json:"token" firestore:"token"`
+}
+
+// APIKeyAuth contains the settings of the auth with an API key
+type APIKeyAuth struct {
+	Key   string `json:"key" firestore:"key"`
+	Value string `json:"value" firestore:"value"`
+	In    string `json:"in" firestore:"in"`
+}
+
+// DigestAuth contains the settings of the HTTP Digest auth
+type DigestAuth struct {
+	Username string `json:"username" firestore:"username"`
+	Password string `json:"password" firestore:"password"`
+}
+
+// AWSV4Auth contains the settings of the auth with AWS Signature Version 4
+type AWSV4Auth struct {
+	AccessKey    string `json:"access_key" firestore:"access_key"`
+	SecretKey    string `json:"secret_key" firestore:"secret_key"`
+	SessionToken string `json:"session_token" firestore:"session_token"`
+	Region       string `json:"region" firestore:"region"`
+	Service      string `json:"service" firestore:"service"`
+}
+
+// OAuth2Auth contains the settings to get an OAuth2 access token
+type OAuth2Auth struct {
+	GrantType    string `json:"grant_type" firestore:"grant_type"`
+	TokenURL     string `json:"token_url" firestore:"token_url"`
+	ClientID     string `json:"client_id" firestore:"client_id"`
+	ClientSecret string `json:"client_secret" firestore:"client_secret"`
+	Username     string `json:"username" firestore:"username"`
+	Password     string `json:"password" firestore:"password"`
+	Scope        string `json:"scope" firestore:"scope"`
+}
+
+// RedactedSecret replaces the secrets of the auth settings saved in the revisions and the audit log
+const RedactedSecret = "[REDACTED]"
+
+// Redacted returns a copy of the auth settings with the passwords, tokens and secret keys replaced
+func (a *Auth) Redacted() *Auth {
+	if a == nil {
+		return nil
+	}
+
+	copy := a.copy()
+	for _, secret := range copy.secrets() {
+		if *secret != "" {
+			*secret = RedactedSecret
+		}
+	}
+
+	return copy
+}
+
+// RestoreSecrets sets the redacted secrets with the values of the same settings in the current auth,
+// the secrets that can't be restored are left empty
+func (a *Auth) RestoreSecrets(current *Auth) {
+	if a == nil {
+		return
+	}
+
+	var currentSecrets map[string]*string
+	if current != nil {
+		currentSecrets = current.secrets()
+	}
+
+	for name, secret := range a.secrets() {
+		if *secret != RedactedSecret {
+			continue
+		}
+
+		*secret = ""
+		if value, ok := currentSecrets[name]; ok {
+			*secret = *value
+		}
+	}
+}
+
+// copy returns a copy of the auth settings that doesn't share the settings of each type
+func (a *Auth) copy() *Auth {
+	copy := *a
+
+	if a.Basic != nil {
+		basic := *a.Basic
+		copy.Basic = &basic
+	}
+	if a.Bearer != nil {
+		bearer := *a.Bearer
+		copy.Bearer = &bearer
+	}
+	if a.APIKey != nil {
+		apiKey := *a.APIKey
+		copy.APIKey = &apiKey
+	}
+	if a.Digest != nil {
+		digest := *a.Digest
+		copy.Digest = &digest
+	}
+	if a.AWSV4 != nil {
+		awsV4 := *a.AWSV4
+		copy.AWSV4 = &awsV4
+	}
+	if a.OAuth2 != nil {
+		oauth2 := *a.OAuth2
+		copy.OAuth2 = &oauth2
+	}
+
+	return &copy
+}
+
+// secrets returns the secret values of the auth settings by name
+func (a *Auth) secrets() map[string]*string {
+	secrets := map[string]*string{}
+
+	if a.Basic != nil {
+		secrets["basic.password"] = &a.Basic.Password
+	}
+	if a.Bearer != nil {
+		secrets["bearer.token"] = &a.Bearer.Token
+	}
+	if a.APIKey != nil {
+		secrets["apikey.value"] = &a.APIKey.Value
+	}
+	if a.Digest != nil {
+		secrets["digest.password"] = &a.Digest.Password
+	}
+	if a.AWSV4 != nil {
+		secrets["awsv4.secret_key"] = &a.AWSV4.SecretKey
+		secrets["awsv4.session_token"] = &a.AWSV4.SessionToken
+	}
+	if a.OAuth2 != nil {
+		secrets["oauth2.client_secret"] = &a.OAuth2.ClientSecret
+		secrets["oauth2.password"] = &a.OAuth2.Password
+	}
+
+	return secrets
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+)
+
+// maxBatchWrites is the maximum number of writes of a Firestore batch
+const maxBatchWrites = 500
+
+// batchWriter writes many documents, committing a batch every time it reaches the maximum size.
+// The writes of the committed batches are kept, so they can be undone if a later batch fails.
+type batchWriter struct {
+	client    *firestore.Client
+	batch     *firestore.WriteBatch
+	writes    int
+	pending   []*undoWrite
+	committed []*undoWrite
+}
+
+// undoWrite undoes a write of a batchWriter, the created documents are removed
+// and the updated documents are updated with the undo updates
+type undoWrite struct {
+	ref     *firestore.DocumentRef
+	updates []firestore.Update
+}
+
+// newBatchWriter returns a new batchWriter
+func (s *Store) newBatchWriter() *batchWriter {
+	return &batchWriter{
+		client: s.Client,
+		batch:  s.Client.Batch(),
+	}
+}
+
+// set adds the write of a document to the current batch
+func (w *batchWriter) set(ctx context.Context, collection, id string, data interface{}) error {
+	if w.writes == maxBatchWrites {
+		if err := w.commit(ctx); err != nil {
+			return err
+		}
+	}
+
+	ref := w.client.Collection(collection).Doc(id)
+	w.batch.Set(ref, data)
+	w.pending = append(w.pending, &undoWrite{ref: ref})
+	w.writes++
+
+	return nil
+}
+
+// update adds the update of some fields of a document to the current batch, the undo updates
+// are applied if a later batch fails (optional, the update is not undone if they are nil)
+func (w *batchWriter) update(ctx context.Context, collection, id string, updates, undo []firestore.Update) error {
+	if w.writes == maxBatchWrites {
+		if err := w.commit(ctx); err != nil {
+			return err
+		}
+	}
+
+	ref := w.client.Collection(collection).Doc(id)
+	w.batch.Update(ref, updates)
+	w.writes++
+
+	if undo != nil {
+		w.pending = append(w.pending, &undoWrite{ref: ref, updates: undo})
+	}
+
+	return nil
+}
+
+// commit commits the writes of the current batch and starts a new one
+func (w *batchWriter) commit(ctx context.Context) error {
+	if w.writes == 0 {
+		return nil
+	}
+
+	if _, err := w.batch.Commit(ctx); err != nil {
+		return err
+	}
+
+	w.batch = w.client.Batch()
+	w.writes = 0
+	w.committed = append(w.committed, w.pending...)
+	w.pending = nil
+
+	return nil
+}
+
+// rollback undoes the writes of the committed batches, it is used when a batch fails so the
+// documents written before are not left behind: the created documents are removed and the
+// updated documents get the undo updates
+func (w *batchWriter) rollback(ctx context.Context) error {
+	batch := w.client.Batch()
+
+	for i, undo := range w.committed {
+		if undo.updates == nil {
+			batch.Delete(undo.ref)
+		} else {
+			batch.Update(undo.ref, undo.updates)
+		}
+
+		if (i+1)%maxBatchWrites == 0 || i == len(w.committed)-1 {
+			if _, err := batch.Commit(ctx); err != nil {
+				return err
+			}
+
+			batch = w.client.Batch()
+		}
+	}
+
+	w.committed = nil
+
+	return nil
+}
+
+// updateRanks updates only the rank of many documents, so it does not overwrite
+// the changes of the other fields done at the same time
+func (s *Store) updateRanks(ctx context.Context, userID, collection string, ranks map[string]string) error {
+	w := s.newBatchWriter()
+	event := NewEvent(userID)
+
+	for id, rank := range ranks {
+		err := w.update(ctx, collection, id, []firestore.Update{
+			{Path: "rank", Value: rank},
+			{Path: "version", Value: firestore.Increment(1)},
+			{Path: "updated", Value: event},
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.commit(ctx)
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+)
+
+// BlobsCollection is the name of the collection
+const BlobsCollection = "blobs"
+
+// MaxBlobSize is the maximum size of the data of a blob, so it fits in a single document
+const MaxBlobSize = 900 * 1024
+
+// Blob represents a model in the database, it contains a file used in the body of the requests
+type Blob struct {
+	ID          string `json:"id" firestore:"id"`
+	ProjectID   string `json:"project_id" firestore:"project_id"`
+	FileName    string `json:"file_name" firestore:"file_name"`
+	ContentType string `json:"content_type" firestore:"content_type"`
+	Size        int    `json:"size" firestore:"size"`
+	Data        []byte `json:"-" firestore:"data"`
+	Created     *Event `json:"created" firestore:"created"`
+}
+
+// NewBlobID generates a UUID for blobs
+func (s *Store) NewBlobID() string {
+	return "blo-" + uuid.New().String()
+}
+
+// CreateBlob creates a new Blob
+func (s *Store) CreateBlob(ctx context.Context, userID string, blob *Blob) error {
+	blob.Size = len(blob.Data)
+	blob.Created = NewEvent(userID)
+	_, err := s.Client.Collection(BlobsCollection).Doc(blob.ID).Set(ctx, blob)
+	return err
+}
+
+// DeleteBlobs deletes many blobs in batches
+func (s *Store) DeleteBlobs(ctx context.Context, ids []string) error {
+	batch := s.Client.Batch()
+
+	for i, id := range ids {
+		batch.Delete(s.Client.Collection(BlobsCollection).Doc(id))
+
+		if (i+1)%maxBatchWrites == 0 || i == len(ids)-1 {
+			if _, err := batch.Commit(ctx); err != nil {
+				return err
+			}
+
+			batch = s.Client.Batch()
+		}
+	}
+
+	return nil
+}
+
+// GetBlobByID gets a Blob by id
+func (s *Store) GetBlobByID(ctx context.Context, id string) (*Blob, error) {
+	iter := s.Client.Collection(BlobsCollection).Where("id", "==", id).Limit(1).Documents(ctx)
+
+	snapshot, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blob := &Blob{}
+	snapshot.DataTo(blob)
+
+	return blob, nil
+}
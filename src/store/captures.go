@@ -0,0 +1,11 @@
+package store
+
+// Capture extracts a value of the response of a request into a variable of the environment.
+// The property is the JSON path, the name of the header or the regular expression according
+// to its source, the regular expressions capture their first group or the whole match.
+type Capture struct {
+	Source   string `json:"source" firestore:"source"`
+	Property string `json:"property" firestore:"property"`
+	Variable string `json:"variable" firestore:"variable"`
+	Enabled  bool   `json:"enabled" firestore:"enabled"`
+}
@@ -3,6 +3,9 @@ package store
 import (
 	"context"
 
+	"apiboy/backend/src/errors"
+
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 )
@@ -16,6 +19,7 @@ type Environment struct {
 	Name      string            `json:"name" firestore:"name"`
 	Variables map[string]string `json:"variables" firestore:"variables"`
 	ProjectID string            `json:"project_id" firestore:"project_id"`
+	Version   int64             `json:"version" firestore:"version"`
 	Created   *Event            `json:"created" firestore:"created"`
 	Updated   *Event            `json:"updated" firestore:"updated"`
 	Deleted   *Event            `json:"deleted" firestore:"deleted"`
@@ -28,23 +32,103 @@ func (s *Store) NewEnvironmentID() string {
 
 // CreateEnvironment creates a new Environment
 func (s *Store) CreateEnvironment(ctx context.Context, userID string, environment *Environment) error {
+	environment.Version = 1
 	environment.Created = NewEvent(userID)
-	_, err := s.Client.Collection(EnvironmentsCollection).Doc(environment.ID).Set(ctx, environment)
+
+	// save the document and its revision at the same time
+	revision := s.newEnvironmentRevision(userID, environment)
+
+	batch := s.Client.Batch()
+	batch.Set(s.Client.Collection(EnvironmentsCollection).Doc(environment.ID), environment)
+	batch.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+
+	_, err := batch.Commit(ctx)
 	return err
 }
 
 // DeleteEnvironment deletes an existing Environment
 func (s *Store) DeleteEnvironment(ctx context.Context, userID string, environment *Environment) error {
+	environment.Version++
 	environment.Deleted = NewEvent(userID)
 	_, err := s.Client.Collection(EnvironmentsCollection).Doc(environment.ID).Set(ctx, environment)
 	return err
 }
 
-// UpdateEnvironment updates an existing environment
+// UpdateEnvironment updates an existing environment if its stored version is the version of the given environment,
+// otherwise it returns a Conflict error with the stored environment
 func (s *Store) UpdateEnvironment(ctx context.Context, userID string, environment *Environment) error {
-	environment.Updated = NewEvent(userID)
-	_, err := s.Client.Collection(EnvironmentsCollection).Doc(environment.ID).Set(ctx, environment)
-	return err
+	ref := s.Client.Collection(EnvironmentsCollection).Doc(environment.ID)
+	expected := environment.Version
+
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		version, err := checkVersion(snapshot, "Environment", expected, &Environment{})
+		if err != nil {
+			return err
+		}
+
+		environment.Version = version + 1
+		environment.Updated = NewEvent(userID)
+
+		// save the document and its revision at the same time
+		revision := s.newEnvironmentRevision(userID, environment)
+
+		if err := tx.Set(ref, environment); err != nil {
+			return err
+		}
+
+		return tx.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+	})
+}
+
+// PatchEnvironment applies a patch to the stored copy of an existing environment, so the fields that are
+// not modified by the patch keep the changes done by other users. If version is not 0 and
+// it is not the stored version, it returns a Conflict error with the stored environment.
+func (s *Store) PatchEnvironment(ctx context.Context, userID, id string, version int64, patch func(environment *Environment)) (*Environment, error) {
+	ref := s.Client.Collection(EnvironmentsCollection).Doc(id)
+	environment := &Environment{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		current, err := checkVersion(snapshot, "Environment", version, &Environment{})
+		if err != nil {
+			return err
+		}
+
+		*environment = Environment{}
+		snapshot.DataTo(environment)
+
+		if environment.Deleted != nil {
+			return errors.NotFound{Obj: "Environment"}
+		}
+
+		patch(environment)
+
+		environment.Version = current + 1
+		environment.Updated = NewEvent(userID)
+
+		// save the document and its revision at the same time
+		revision := s.newEnvironmentRevision(userID, environment)
+
+		if err := tx.Set(ref, environment); err != nil {
+			return err
+		}
+
+		return tx.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return environment, nil
 }
 
 // GetEnvironmentByID gets a Environment by id
@@ -68,3 +152,24 @@ func (s *Store) GetEnvironmentByID(ctx context.Context, id string) (*Environment
 
 	return environment, nil
 }
+
+// GetEnvironmentsByProjectID gets the environments of a project
+func (s *Store) GetEnvironmentsByProjectID(ctx context.Context, projectID string) ([]*Environment, error) {
+	snapshots, err := s.Client.Collection(EnvironmentsCollection).Where("project_id", "==", projectID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	environments := []*Environment{}
+
+	for _, snapshot := range snapshots {
+		environment := &Environment{}
+		snapshot.DataTo(environment)
+
+		if environment.Deleted == nil {
+			environments = append(environments, environment)
+		}
+	}
+
+	return environments, nil
+}
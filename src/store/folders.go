@@ -2,7 +2,11 @@ package store
 
 import (
 	"context"
+	"fmt"
 
+	"apiboy/backend/src/errors"
+
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 )
@@ -10,14 +14,25 @@ import (
 // FoldersCollection is the name of the collection
 const FoldersCollection = "folders"
 
-// Folder represents a model in the database
+// Folder represents a model in the database, the folders without a parent are in the root
+// of the project. The base url, headers, variables and auth are the defaults of the requests
+// of the folder and its subfolders.
 type Folder struct {
-	ID        string `json:"id" firestore:"id"`
-	Name      string `json:"name" firestore:"name"`
-	ProjectID string `json:"project_id" firestore:"project_id"`
-	Created   *Event `json:"created" firestore:"created"`
-	Updated   *Event `json:"updated" firestore:"updated"`
-	Deleted   *Event `json:"deleted" firestore:"deleted"`
+	ID               string            `json:"id" firestore:"id"`
+	Name             string            `json:"name" firestore:"name"`
+	ProjectID        string            `json:"project_id" firestore:"project_id"`
+	ParentFolderID   string            `json:"parent_folder_id" firestore:"parent_folder_id"`
+	Rank             string            `json:"rank" firestore:"rank"`
+	BaseURL          string            `json:"base_url" firestore:"base_url"`
+	Headers          []*Param          `json:"headers" firestore:"headers"`
+	Variables        map[string]string `json:"variables" firestore:"variables"`
+	Auth             *Auth             `json:"auth" firestore:"auth"`
+	PreRequestScript string            `json:"pre_request_script" firestore:"pre_request_script"`
+	TestScript       string            `json:"test_script" firestore:"test_script"`
+	Version          int64             `json:"version" firestore:"version"`
+	Created          *Event            `json:"created" firestore:"created"`
+	Updated          *Event            `json:"updated" firestore:"updated"`
+	Deleted          *Event            `json:"deleted" firestore:"deleted"`
 }
 
 // NewFolderID generates a UUID for folders
@@ -27,6 +42,7 @@ func (s *Store) NewFolderID() string {
 
 // CreateFolder creates a new Folder
 func (s *Store) CreateFolder(ctx context.Context, userID string, folder *Folder) error {
+	folder.Version = 1
 	folder.Created = NewEvent(userID)
 	_, err := s.Client.Collection(FoldersCollection).Doc(folder.ID).Set(ctx, folder)
 	return err
@@ -34,16 +50,150 @@ func (s *Store) CreateFolder(ctx context.Context, userID string, folder *Folder)
 
 // DeleteFolder deletes an existing folder
 func (s *Store) DeleteFolder(ctx context.Context, userID string, folder *Folder) error {
+	folder.Version++
 	folder.Deleted = NewEvent(userID)
 	_, err := s.Client.Collection(FoldersCollection).Doc(folder.ID).Set(ctx, folder)
 	return err
 }
 
-// UpdateFolder updates an existing folder
+// UpdateFolder updates an existing folder if its stored version is the version of the given folder,
+// otherwise it returns a Conflict error with the stored folder
 func (s *Store) UpdateFolder(ctx context.Context, userID string, folder *Folder) error {
-	folder.Updated = NewEvent(userID)
-	_, err := s.Client.Collection(FoldersCollection).Doc(folder.ID).Set(ctx, folder)
-	return err
+	ref := s.Client.Collection(FoldersCollection).Doc(folder.ID)
+	expected := folder.Version
+
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		version, err := checkVersion(snapshot, "Folder", expected, &Folder{})
+		if err != nil {
+			return err
+		}
+
+		folder.Version = version + 1
+		folder.Updated = NewEvent(userID)
+
+		return tx.Set(ref, folder)
+	})
+}
+
+// PatchFolder applies a patch to the stored copy of an existing folder, so the fields that are
+// not modified by the patch keep the changes done by other users. If version is not 0 and
+// it is not the stored version, it returns a Conflict error with the stored folder.
+func (s *Store) PatchFolder(ctx context.Context, userID, id string, version int64, patch func(folder *Folder)) (*Folder, error) {
+	ref := s.Client.Collection(FoldersCollection).Doc(id)
+	folder := &Folder{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		current, err := checkVersion(snapshot, "Folder", version, &Folder{})
+		if err != nil {
+			return err
+		}
+
+		*folder = Folder{}
+		snapshot.DataTo(folder)
+
+		if folder.Deleted != nil {
+			return errors.NotFound{Obj: "Folder"}
+		}
+
+		patch(folder)
+
+		folder.Version = current + 1
+		folder.Updated = NewEvent(userID)
+
+		return tx.Set(ref, folder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// MoveFolder applies a patch to the stored copy of an existing folder like PatchFolder, and moves it to a parent
+// folder of its project (the root of the project if empty). The parent folders are read in the same transaction,
+// so a folder moved at the same time can't create a cycle: the parent folder can't be the folder or one of its
+// subfolders, and the parent folders and the levels of the folder and its subfolders can't exceed the maximum depth.
+func (s *Store) MoveFolder(ctx context.Context, userID, id string, version int64, parentFolderID string, levels, maxDepth int, patch func(folder *Folder)) (*Folder, error) {
+	ref := s.Client.Collection(FoldersCollection).Doc(id)
+	folder := &Folder{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		current, err := checkVersion(snapshot, "Folder", version, &Folder{})
+		if err != nil {
+			return err
+		}
+
+		*folder = Folder{}
+		snapshot.DataTo(folder)
+
+		if folder.Deleted != nil {
+			return errors.NotFound{Obj: "Folder"}
+		}
+
+		// check the parent folders, from the nearest to the farthest
+		depth := 0
+
+		for parentID := parentFolderID; parentID != ""; {
+			if parentID == folder.ID {
+				return errors.BadRequest{Msg: "Invalid parent folder, a folder can't be moved into itself"}
+			}
+
+			depth++
+			if depth+levels > maxDepth {
+				return errors.BadRequest{Msg: "Maximum folder depth exceeded"}
+			}
+
+			snapshots, err := tx.GetAll([]*firestore.DocumentRef{s.Client.Collection(FoldersCollection).Doc(parentID)})
+			if err != nil {
+				return err
+			}
+
+			parent := &Folder{}
+			if snapshots[0].Exists() {
+				snapshots[0].DataTo(parent)
+			}
+
+			if !snapshots[0].Exists() || parent.Deleted != nil {
+				return errors.NotFound{Obj: "Parent folder"}
+			} else if parent.ProjectID != folder.ProjectID {
+				return errors.BadRequest{Msg: "Invalid parent folder for project"}
+			}
+
+			parentID = parent.ParentFolderID
+		}
+
+		if levels > maxDepth {
+			return errors.BadRequest{Msg: "Maximum folder depth exceeded"}
+		}
+
+		patch(folder)
+
+		folder.ParentFolderID = parentFolderID
+		folder.Version = current + 1
+		folder.Updated = NewEvent(userID)
+
+		return tx.Set(ref, folder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return folder, nil
 }
 
 // GetFolderByID gets a Folder by id
@@ -67,3 +217,403 @@ func (s *Store) GetFolderByID(ctx context.Context, id string) (*Folder, error) {
 
 	return folder, nil
 }
+
+// GetDeletedFolderByID gets a Folder by id only if it is deleted
+func (s *Store) GetDeletedFolderByID(ctx context.Context, id string) (*Folder, error) {
+	iter := s.Client.Collection(FoldersCollection).Where("id", "==", id).Limit(1).Documents(ctx)
+
+	snapshot, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	folder := &Folder{}
+	snapshot.DataTo(folder)
+
+	if folder.Deleted == nil {
+		return nil, nil
+	}
+
+	return folder, nil
+}
+
+// GetFoldersByParentFolderID gets the folders of a folder, or of the root of a project if the parent folder is empty
+func (s *Store) GetFoldersByParentFolderID(ctx context.Context, projectID, parentFolderID string) ([]*Folder, error) {
+	snapshots, err := s.Client.Collection(FoldersCollection).
+		Where("project_id", "==", projectID).
+		Where("parent_folder_id", "==", parentFolderID).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	folders := []*Folder{}
+
+	for _, snapshot := range snapshots {
+		folder := &Folder{}
+		snapshot.DataTo(folder)
+
+		if folder.Deleted == nil {
+			folders = append(folders, folder)
+		}
+	}
+
+	return folders, nil
+}
+
+// UpdateFolderRanks updates the ranks of many folders, the ranks are mapped by folder id
+func (s *Store) UpdateFolderRanks(ctx context.Context, userID string, ranks map[string]string) error {
+	return s.updateRanks(ctx, userID, FoldersCollection, ranks)
+}
+
+// GetFoldersByProjectID gets the deleted or the not deleted folders of a project
+func (s *Store) GetFoldersByProjectID(ctx context.Context, projectID string, deleted bool) ([]*Folder, error) {
+	snapshots, err := s.Client.Collection(FoldersCollection).Where("project_id", "==", projectID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	folders := []*Folder{}
+
+	for _, snapshot := range snapshots {
+		folder := &Folder{}
+		snapshot.DataTo(folder)
+
+		if (folder.Deleted != nil) == deleted {
+			folders = append(folders, folder)
+		}
+	}
+
+	return folders, nil
+}
+
+// CreateFolderTree creates many folders and requests in batches, the first folder is the top of the tree
+// and it is written at the end, so the tree is not reachable until all of its documents are written.
+// The batches are not a single atomic transaction: if a batch fails, the documents written by the previous
+// batches are removed, and if the removal fails too both errors are returned.
+func (s *Store) CreateFolderTree(ctx context.Context, userID string, folders []*Folder, requests []*Request) error {
+	if len(folders) == 0 {
+		return nil
+	}
+
+	w := s.newBatchWriter()
+
+	err := func() error {
+		if err := s.setNewFolderTree(ctx, w, userID, folders[1:], requests); err != nil {
+			return err
+		}
+
+		if err := s.setNewFolderTree(ctx, w, userID, folders[:1], nil); err != nil {
+			return err
+		}
+
+		return w.commit(ctx)
+	}()
+	if err != nil {
+		if rollbackErr := w.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%v (and the written documents could not be removed: %v)", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// setNewFolderTree adds the writes to create many folders and requests to a batchWriter
+func (s *Store) setNewFolderTree(ctx context.Context, w *batchWriter, userID string, folders []*Folder, requests []*Request) error {
+	for _, folder := range folders {
+		folder.Version = 1
+		folder.Created = NewEvent(userID)
+
+		if err := w.set(ctx, FoldersCollection, folder.ID, folder); err != nil {
+			return err
+		}
+	}
+
+	for _, request := range requests {
+		request.Version = 1
+		request.Created = NewEvent(userID)
+
+		// save the document and its revision
+		revision := s.newRequestRevision(userID, request)
+
+		if err := w.set(ctx, RequestsCollection, request.ID, request); err != nil {
+			return err
+		}
+
+		if err := w.set(ctx, RevisionsCollection, revision.ID, revision); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// movedFolderFields and movedRequestFields are the fields changed by MoveFolderTree,
+// they are restored if the move fails
+var (
+	movedFolderFields  = []string{"project_id", "parent_folder_id", "rank"}
+	movedRequestFields = []string{"project_id", "folder_id", "rank", "multipart_parts", "binary_blob_id"}
+)
+
+// movedDocument is a document written by MoveFolderTree, with the stored values of its moved fields
+type movedDocument struct {
+	ref      *firestore.DocumentRef
+	obj      string
+	version  int64
+	current  interface{}
+	fields   []string
+	writes   int
+	write    func(tx *firestore.Transaction, version int64) error
+	original map[string]interface{}
+}
+
+// MoveFolderTree saves many folders and requests moved to another project, with their changed project, parent
+// folder, rank and files of the body. The documents are written in chunks of transactions, and each document
+// is only written if its stored version is the version of the given document, otherwise it returns a Conflict
+// error with the stored document. If a chunk fails, the moved fields of the documents written by the previous
+// chunks are restored, and if they can't be restored both errors are returned.
+func (s *Store) MoveFolderTree(ctx context.Context, userID string, folders []*Folder, requests []*Request) error {
+	event := NewEvent(userID)
+	docs := []*movedDocument{}
+
+	for _, folder := range folders {
+		folder := folder
+
+		docs = append(docs, &movedDocument{
+			ref:     s.Client.Collection(FoldersCollection).Doc(folder.ID),
+			obj:     "Folder",
+			version: folder.Version,
+			current: &Folder{},
+			fields:  movedFolderFields,
+			writes:  1,
+			write: func(tx *firestore.Transaction, version int64) error {
+				folder.Version = version
+				folder.Updated = event
+
+				return tx.Set(s.Client.Collection(FoldersCollection).Doc(folder.ID), folder)
+			},
+		})
+	}
+
+	for _, request := range requests {
+		request := request
+
+		// save the document and its revision
+		docs = append(docs, &movedDocument{
+			ref:     s.Client.Collection(RequestsCollection).Doc(request.ID),
+			obj:     "Request",
+			version: request.Version,
+			current: &Request{},
+			fields:  movedRequestFields,
+			writes:  2,
+			write: func(tx *firestore.Transaction, version int64) error {
+				request.Version = version
+				request.Updated = event
+
+				if err := tx.Set(s.Client.Collection(RequestsCollection).Doc(request.ID), request); err != nil {
+					return err
+				}
+
+				revision := s.newRequestRevision(userID, request)
+
+				return tx.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+			},
+		})
+	}
+
+	committed := []*movedDocument{}
+
+	for start := 0; start < len(docs); {
+		end, writes := start, 0
+		for end < len(docs) && writes+docs[end].writes <= maxBatchWrites {
+			writes += docs[end].writes
+			end++
+		}
+
+		if err := s.moveDocuments(ctx, docs[start:end]); err != nil {
+			if undoErr := s.undoMovedDocuments(ctx, committed); undoErr != nil {
+				return fmt.Errorf("%v (and the moved documents could not be restored: %v)", err, undoErr)
+			}
+
+			return err
+		}
+
+		committed = append(committed, docs[start:end]...)
+		start = end
+	}
+
+	return nil
+}
+
+// moveDocuments writes a chunk of the documents of MoveFolderTree in a transaction,
+// and keeps the stored values of their moved fields
+func (s *Store) moveDocuments(ctx context.Context, docs []*movedDocument) error {
+	refs := []*firestore.DocumentRef{}
+	for _, doc := range docs {
+		refs = append(refs, doc.ref)
+	}
+
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshots, err := tx.GetAll(refs)
+		if err != nil {
+			return err
+		}
+
+		for i, doc := range docs {
+			if !snapshots[i].Exists() {
+				return errors.NotFound{Obj: doc.obj}
+			}
+
+			version, err := checkVersion(snapshots[i], doc.obj, doc.version, doc.current)
+			if err != nil {
+				return err
+			}
+
+			data := snapshots[i].Data()
+			doc.original = map[string]interface{}{}
+
+			for _, field := range doc.fields {
+				doc.original[field] = data[field]
+			}
+
+			if err := doc.write(tx, version+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// undoMovedDocuments restores the moved fields of the documents written by MoveFolderTree,
+// only those fields are updated, so the changes done after the move by other users are kept
+func (s *Store) undoMovedDocuments(ctx context.Context, docs []*movedDocument) error {
+	w := s.newBatchWriter()
+
+	for _, doc := range docs {
+		updates := []firestore.Update{{Path: "version", Value: firestore.Increment(1)}}
+		for _, field := range doc.fields {
+			updates = append(updates, firestore.Update{Path: field, Value: doc.original[field]})
+		}
+
+		if err := w.update(ctx, doc.ref.Parent.ID, doc.ref.ID, updates, nil); err != nil {
+			return err
+		}
+	}
+
+	return w.commit(ctx)
+}
+
+// DeleteFolderTree deletes many folders and requests with the same event, in batches, so they can be restored
+// together. Only the deletion fields are updated, so the changes done at the same time by other users are kept,
+// and if a batch fails the documents deleted by the previous batches are restored.
+func (s *Store) DeleteFolderTree(ctx context.Context, userID string, folders []*Folder, requests []*Request) error {
+	event := NewEvent(userID)
+	w := s.newBatchWriter()
+
+	update := func(collection, id string) error {
+		return w.update(ctx, collection, id, []firestore.Update{
+			{Path: "deleted", Value: event},
+			{Path: "version", Value: firestore.Increment(1)},
+		}, []firestore.Update{
+			{Path: "deleted", Value: nil},
+			{Path: "version", Value: firestore.Increment(1)},
+		})
+	}
+
+	err := func() error {
+		for _, folder := range folders {
+			if err := update(FoldersCollection, folder.ID); err != nil {
+				return err
+			}
+		}
+
+		for _, request := range requests {
+			if err := update(RequestsCollection, request.ID); err != nil {
+				return err
+			}
+		}
+
+		return w.commit(ctx)
+	}()
+	if err != nil {
+		if rollbackErr := w.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%v (and the deleted documents could not be restored: %v)", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	for _, folder := range folders {
+		folder.Version++
+		folder.Deleted = event
+	}
+
+	for _, request := range requests {
+		request.Version++
+		request.Deleted = event
+	}
+
+	return nil
+}
+
+// RestoreFolderTree restores many deleted folders and requests at the same time, in batches. Only the deletion
+// fields and the parent folder of the folders are updated, and if a batch fails the documents restored
+// by the previous batches are deleted again.
+func (s *Store) RestoreFolderTree(ctx context.Context, userID string, folders []*Folder, requests []*Request) error {
+	event := NewEvent(userID)
+	w := s.newBatchWriter()
+
+	update := func(collection, id string, deleted *Event, updates ...firestore.Update) error {
+		return w.update(ctx, collection, id, append([]firestore.Update{
+			{Path: "deleted", Value: nil},
+			{Path: "updated", Value: event},
+			{Path: "version", Value: firestore.Increment(1)},
+		}, updates...), []firestore.Update{
+			{Path: "deleted", Value: deleted},
+			{Path: "version", Value: firestore.Increment(1)},
+		})
+	}
+
+	err := func() error {
+		for _, folder := range folders {
+			if err := update(FoldersCollection, folder.ID, folder.Deleted, firestore.Update{Path: "parent_folder_id", Value: folder.ParentFolderID}); err != nil {
+				return err
+			}
+		}
+
+		for _, request := range requests {
+			if err := update(RequestsCollection, request.ID, request.Deleted); err != nil {
+				return err
+			}
+		}
+
+		return w.commit(ctx)
+	}()
+	if err != nil {
+		if rollbackErr := w.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%v (and the restored documents could not be deleted again: %v)", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	for _, folder := range folders {
+		folder.Version++
+		folder.Updated = event
+		folder.Deleted = nil
+	}
+
+	for _, request := range requests {
+		request.Version++
+		request.Updated = event
+		request.Deleted = nil
+	}
+
+	return nil
+}
@@ -2,7 +2,11 @@ package store
 
 import (
 	"context"
+	"fmt"
 
+	"apiboy/backend/src/errors"
+
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 )
@@ -10,13 +14,19 @@ import (
 // ProjectsCollection is the name of the collection
 const ProjectsCollection = "projects"
 
-// Project represents a model in the database
+// Project represents a model in the database, the base url, headers, variables
+// and auth are the defaults of the requests of the project
 type Project struct {
-	ID      string `json:"id" firestore:"id"`
-	Name    string `json:"name" firestore:"name"`
-	Created *Event `json:"created" firestore:"created"`
-	Updated *Event `json:"updated" firestore:"updated"`
-	Deleted *Event `json:"deleted" firestore:"deleted"`
+	ID        string            `json:"id" firestore:"id"`
+	Name      string            `json:"name" firestore:"name"`
+	BaseURL   string            `json:"base_url" firestore:"base_url"`
+	Headers   []*Param          `json:"headers" firestore:"headers"`
+	Variables map[string]string `json:"variables" firestore:"variables"`
+	Auth      *Auth             `json:"auth" firestore:"auth"`
+	Version   int64             `json:"version" firestore:"version"`
+	Created   *Event            `json:"created" firestore:"created"`
+	Updated   *Event            `json:"updated" firestore:"updated"`
+	Deleted   *Event            `json:"deleted" firestore:"deleted"`
 }
 
 // NewProjectID generates a UUID for Projects
@@ -26,20 +36,130 @@ func (s *Store) NewProjectID() string {
 
 // CreateProject creates a new Project
 func (s *Store) CreateProject(ctx context.Context, userID string, project *Project) error {
+	project.Version = 1
 	project.Created = NewEvent(userID)
 	_, err := s.Client.Collection(ProjectsCollection).Doc(project.ID).Set(ctx, project)
 	return err
 }
 
-// UpdateProject updates an existing Project
+// CreateProjectTree creates a project with its folders, requests and environments in batches. The project
+// and its relationship with the user are written at the end, so the project is not reachable until all of
+// its documents are written. The batches are not a single atomic transaction: if a batch fails, the documents
+// written by the previous batches are removed, and if the removal fails too both errors are returned.
+func (s *Store) CreateProjectTree(ctx context.Context, userID string, project *Project, projectUser *ProjectUser, folders []*Folder, requests []*Request, environments []*Environment) error {
+	w := s.newBatchWriter()
+
+	err := func() error {
+		if err := s.setNewFolderTree(ctx, w, userID, folders, requests); err != nil {
+			return err
+		}
+
+		for _, environment := range environments {
+			environment.Version = 1
+			environment.Created = NewEvent(userID)
+
+			// save the document and its revision
+			revision := s.newEnvironmentRevision(userID, environment)
+
+			if err := w.set(ctx, EnvironmentsCollection, environment.ID, environment); err != nil {
+				return err
+			}
+
+			if err := w.set(ctx, RevisionsCollection, revision.ID, revision); err != nil {
+				return err
+			}
+		}
+
+		project.Version = 1
+		project.Created = NewEvent(userID)
+
+		if err := w.set(ctx, ProjectsCollection, project.ID, project); err != nil {
+			return err
+		}
+
+		if err := w.set(ctx, ProjectUsersCollection, projectUser.ID, projectUser); err != nil {
+			return err
+		}
+
+		return w.commit(ctx)
+	}()
+	if err != nil {
+		if rollbackErr := w.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%v (and the written documents could not be removed: %v)", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// UpdateProject updates an existing project if its stored version is the version of the given project,
+// otherwise it returns a Conflict error with the stored project
 func (s *Store) UpdateProject(ctx context.Context, userID string, project *Project) error {
-	project.Updated = NewEvent(userID)
-	_, err := s.Client.Collection(ProjectsCollection).Doc(project.ID).Set(ctx, project)
-	return err
+	ref := s.Client.Collection(ProjectsCollection).Doc(project.ID)
+	expected := project.Version
+
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		version, err := checkVersion(snapshot, "Project", expected, &Project{})
+		if err != nil {
+			return err
+		}
+
+		project.Version = version + 1
+		project.Updated = NewEvent(userID)
+
+		return tx.Set(ref, project)
+	})
+}
+
+// PatchProject applies a patch to the stored copy of an existing project, so the fields that are
+// not modified by the patch keep the changes done by other users. If version is not 0 and
+// it is not the stored version, it returns a Conflict error with the stored project.
+func (s *Store) PatchProject(ctx context.Context, userID, id string, version int64, patch func(project *Project)) (*Project, error) {
+	ref := s.Client.Collection(ProjectsCollection).Doc(id)
+	project := &Project{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		current, err := checkVersion(snapshot, "Project", version, &Project{})
+		if err != nil {
+			return err
+		}
+
+		*project = Project{}
+		snapshot.DataTo(project)
+
+		if project.Deleted != nil {
+			return errors.NotFound{Obj: "Project"}
+		}
+
+		patch(project)
+
+		project.Version = current + 1
+		project.Updated = NewEvent(userID)
+
+		return tx.Set(ref, project)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
 }
 
 // DeleteProject deletes an existing project
 func (s *Store) DeleteProject(ctx context.Context, userID string, project *Project) error {
+	project.Version++
 	project.Deleted = NewEvent(userID)
 	_, err := s.Client.Collection(ProjectsCollection).Doc(project.ID).Set(ctx, project)
 	return err
@@ -55,3 +55,22 @@ func (s *Store) GetProjectUserByID(ctx context.Context, id string) (*ProjectUser
 func (s *Store) GetProjectUserByProjectIDAndUserID(ctx context.Context, projectID string, userID string) (*ProjectUser, error) {
 	return s.GetProjectUserByID(ctx, s.NewProjectUserID(projectID, userID))
 }
+
+// GetProjectUsersByUserID gets all the ProjectUsers of a user
+func (s *Store) GetProjectUsersByUserID(ctx context.Context, userID string) ([]*ProjectUser, error) {
+	snapshots, err := s.Client.Collection(ProjectUsersCollection).Where("user_id", "==", userID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	projectusers := []*ProjectUser{}
+
+	for _, snapshot := range snapshots {
+		projectuser := &ProjectUser{}
+		snapshot.DataTo(projectuser)
+
+		projectusers = append(projectusers, projectuser)
+	}
+
+	return projectusers, nil
+}
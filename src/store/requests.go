@@ -2,7 +2,12 @@ package store
 
 import (
 	"context"
+	"sort"
 
+	"apiboy/backend/src/enums"
+	"apiboy/backend/src/errors"
+
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 )
@@ -12,17 +17,82 @@ const RequestsCollection = "requests"
 
 // Request represents a model in the database
 type Request struct {
-	ID        string            `json:"id" firestore:"id"`
-	Name      string            `json:"name" firestore:"name"`
-	FolderID  string            `json:"folder_id" firestore:"folder_id"`
-	ProjectID string            `json:"project_id" firestore:"project_id"`
-	Type      string            `json:"type" firestore:"type"`
-	URL       string            `json:"url" firestore:"url"`
-	Headers   map[string]string `json:"headers" firestore:"headers"`
-	Body      string            `json:"body" firestore:"body"`
-	Created   *Event            `json:"created" firestore:"created"`
-	Updated   *Event            `json:"updated" firestore:"updated"`
-	Deleted   *Event            `json:"deleted" firestore:"deleted"`
+	ID               string            `json:"id" firestore:"id"`
+	Name             string            `json:"name" firestore:"name"`
+	FolderID         string            `json:"folder_id" firestore:"folder_id"`
+	ProjectID        string            `json:"project_id" firestore:"project_id"`
+	Rank             string            `json:"rank" firestore:"rank"`
+	Type             string            `json:"type" firestore:"type"`
+	URL              string            `json:"url" firestore:"url"`
+	QueryParams      []*Param          `json:"query_params" firestore:"query_params"`
+	Headers          []*Param          `json:"headers" firestore:"header_params"`
+	BodyMode         string            `json:"body_mode" firestore:"body_mode"`
+	Body             string            `json:"body" firestore:"body"`
+	BodyContentType  string            `json:"body_content_type" firestore:"body_content_type"`
+	FormParams       []*Param          `json:"form_params" firestore:"form_params"`
+	MultipartParts   []*MultipartPart  `json:"multipart_parts" firestore:"multipart_parts"`
+	BinaryBlobID     string            `json:"binary_blob_id" firestore:"binary_blob_id"`
+	GraphQL          *GraphQLBody      `json:"graphql" firestore:"graphql"`
+	Auth             *Auth             `json:"auth" firestore:"auth"`
+	Assertions       []*Assertion      `json:"assertions" firestore:"assertions"`
+	Captures         []*Capture        `json:"captures" firestore:"captures"`
+	PreRequestScript string            `json:"pre_request_script" firestore:"pre_request_script"`
+	TestScript       string            `json:"test_script" firestore:"test_script"`
+	Version          int64             `json:"version" firestore:"version"`
+	Created          *Event            `json:"created" firestore:"created"`
+	Updated          *Event            `json:"updated" firestore:"updated"`
+	Deleted          *Event            `json:"deleted" firestore:"deleted"`
+	LegacyHeaders    map[string]string `json:"-" firestore:"headers,omitempty"`
+}
+
+// Param is a key and value pair of a request, like a header or a query param
+type Param struct {
+	Key         string `json:"key" firestore:"key"`
+	Value       string `json:"value" firestore:"value"`
+	Enabled     bool   `json:"enabled" firestore:"enabled"`
+	Description string `json:"description" firestore:"description"`
+}
+
+// MultipartPart is a part of a multipart/form-data body, with a text value or the content of a blob
+type MultipartPart struct {
+	Key         string `json:"key" firestore:"key"`
+	Type        string `json:"type" firestore:"type"`
+	Value       string `json:"value" firestore:"value"`
+	BlobID      string `json:"blob_id" firestore:"blob_id"`
+	ContentType string `json:"content_type" firestore:"content_type"`
+	Enabled     bool   `json:"enabled" firestore:"enabled"`
+	Description string `json:"description" firestore:"description"`
+}
+
+// GraphQLBody is the body of a GraphQL request, the variables are a JSON object
+type GraphQLBody struct {
+	Query     string `json:"query" firestore:"query"`
+	Variables string `json:"variables" firestore:"variables"`
+}
+
+// migrate converts the data saved by older versions of the request
+func (r *Request) migrate() {
+	// the headers were saved as a map, so they are sorted by key to get a stable order
+	if r.Headers == nil && len(r.LegacyHeaders) > 0 {
+		keys := []string{}
+
+		for key := range r.LegacyHeaders {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			r.Headers = append(r.Headers, &Param{Key: key, Value: r.LegacyHeaders[key], Enabled: true})
+		}
+	}
+
+	r.LegacyHeaders = nil
+
+	// the body was always raw text
+	if r.BodyMode == "" {
+		r.BodyMode = enums.BodyModeRaw
+	}
 }
 
 // NewRequestID generates a UUID for requests
@@ -32,23 +102,104 @@ func (s *Store) NewRequestID() string {
 
 // CreateRequest creates a new request
 func (s *Store) CreateRequest(ctx context.Context, userID string, request *Request) error {
+	request.Version = 1
 	request.Created = NewEvent(userID)
-	_, err := s.Client.Collection(RequestsCollection).Doc(request.ID).Set(ctx, request)
+
+	// save the document and its revision at the same time
+	revision := s.newRequestRevision(userID, request)
+
+	batch := s.Client.Batch()
+	batch.Set(s.Client.Collection(RequestsCollection).Doc(request.ID), request)
+	batch.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+
+	_, err := batch.Commit(ctx)
 	return err
 }
 
 // DeleteRequest deletes an existing request
 func (s *Store) DeleteRequest(ctx context.Context, userID string, request *Request) error {
+	request.Version++
 	request.Deleted = NewEvent(userID)
 	_, err := s.Client.Collection(RequestsCollection).Doc(request.ID).Set(ctx, request)
 	return err
 }
 
-// UpdateRequest updates an existing request
+// UpdateRequest updates an existing request if its stored version is the version of the given request,
+// otherwise it returns a Conflict error with the stored request
 func (s *Store) UpdateRequest(ctx context.Context, userID string, request *Request) error {
-	request.Updated = NewEvent(userID)
-	_, err := s.Client.Collection(RequestsCollection).Doc(request.ID).Set(ctx, request)
-	return err
+	ref := s.Client.Collection(RequestsCollection).Doc(request.ID)
+	expected := request.Version
+
+	return s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		version, err := checkVersion(snapshot, "Request", expected, &Request{})
+		if err != nil {
+			return err
+		}
+
+		request.Version = version + 1
+		request.Updated = NewEvent(userID)
+
+		// save the document and its revision at the same time
+		revision := s.newRequestRevision(userID, request)
+
+		if err := tx.Set(ref, request); err != nil {
+			return err
+		}
+
+		return tx.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+	})
+}
+
+// PatchRequest applies a patch to the stored copy of an existing request, so the fields that are
+// not modified by the patch keep the changes done by other users. If version is not 0 and
+// it is not the stored version, it returns a Conflict error with the stored request.
+func (s *Store) PatchRequest(ctx context.Context, userID, id string, version int64, patch func(request *Request)) (*Request, error) {
+	ref := s.Client.Collection(RequestsCollection).Doc(id)
+	request := &Request{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		current, err := checkVersion(snapshot, "Request", version, &Request{})
+		if err != nil {
+			return err
+		}
+
+		*request = Request{}
+		snapshot.DataTo(request)
+		request.migrate()
+
+		if request.Deleted != nil {
+			return errors.NotFound{Obj: "Request"}
+		}
+
+		patch(request)
+
+		request.Version = current + 1
+		request.Updated = NewEvent(userID)
+
+		// save the document and its revision at the same time
+		revision := s.newRequestRevision(userID, request)
+
+		if err := tx.Set(ref, request); err != nil {
+			return err
+		}
+
+		return tx.Set(s.Client.Collection(RevisionsCollection).Doc(revision.ID), revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
 }
 
 // GetRequestByID gets a Request by id
@@ -65,6 +216,7 @@ func (s *Store) GetRequestByID(ctx context.Context, id string) (*Request, error)
 
 	request := &Request{}
 	snapshot.DataTo(request)
+	request.migrate()
 
 	if request.Deleted != nil {
 		return nil, nil
@@ -72,3 +224,52 @@ func (s *Store) GetRequestByID(ctx context.Context, id string) (*Request, error)
 
 	return request, nil
 }
+
+// GetRequestsByProjectID gets the deleted or the not deleted requests of a project
+func (s *Store) GetRequestsByProjectID(ctx context.Context, projectID string, deleted bool) ([]*Request, error) {
+	snapshots, err := s.Client.Collection(RequestsCollection).Where("project_id", "==", projectID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []*Request{}
+
+	for _, snapshot := range snapshots {
+		request := &Request{}
+		snapshot.DataTo(request)
+		request.migrate()
+
+		if (request.Deleted != nil) == deleted {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, nil
+}
+
+// GetRequestsByFolderID gets the requests of a folder
+func (s *Store) GetRequestsByFolderID(ctx context.Context, folderID string) ([]*Request, error) {
+	snapshots, err := s.Client.Collection(RequestsCollection).Where("folder_id", "==", folderID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []*Request{}
+
+	for _, snapshot := range snapshots {
+		request := &Request{}
+		snapshot.DataTo(request)
+		request.migrate()
+
+		if request.Deleted == nil {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, nil
+}
+
+// UpdateRequestRanks updates the ranks of many requests, the ranks are mapped by request id
+func (s *Store) UpdateRequestRanks(ctx context.Context, userID string, ranks map[string]string) error {
+	return s.updateRanks(ctx, userID, RequestsCollection, ranks)
+}
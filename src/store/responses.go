@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+)
+
+// ResponsesCollection is the name of the collection
+const ResponsesCollection = "responses"
+
+// MaxResponseBodySize is the maximum size of the body of a response, so it fits in a single document
+const MaxResponseBodySize = 900 * 1024
+
+// Response represents a model in the database, it contains a response of a request saved as an example
+type Response struct {
+	ID         string   `json:"id" firestore:"id"`
+	Name       string   `json:"name" firestore:"name"`
+	RequestID  string   `json:"request_id" firestore:"request_id"`
+	ProjectID  string   `json:"project_id" firestore:"project_id"`
+	StatusCode int      `json:"status_code" firestore:"status_code"`
+	Headers    []*Param `json:"headers" firestore:"headers"`
+	Body       string   `json:"body" firestore:"body"`
+	Created    *Event   `json:"created" firestore:"created"`
+	Deleted    *Event   `json:"deleted" firestore:"deleted"`
+}
+
+// NewResponseID generates a UUID for responses
+func (s *Store) NewResponseID() string {
+	return "res-" + uuid.New().String()
+}
+
+// CreateResponse creates a new Response
+func (s *Store) CreateResponse(ctx context.Context, userID string, response *Response) error {
+	response.Created = NewEvent(userID)
+	_, err := s.Client.Collection(ResponsesCollection).Doc(response.ID).Set(ctx, response)
+	return err
+}
+
+// DeleteResponse deletes an existing Response
+func (s *Store) DeleteResponse(ctx context.Context, userID string, response *Response) error {
+	response.Deleted = NewEvent(userID)
+	_, err := s.Client.Collection(ResponsesCollection).Doc(response.ID).Set(ctx, response)
+	return err
+}
+
+// GetResponseByID gets a Response by id
+func (s *Store) GetResponseByID(ctx context.Context, id string) (*Response, error) {
+	iter := s.Client.Collection(ResponsesCollection).Where("id", "==", id).Limit(1).Documents(ctx)
+
+	snapshot, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{}
+	snapshot.DataTo(response)
+
+	if response.Deleted != nil {
+		return nil, nil
+	}
+
+	return response, nil
+}
+
+// GetResponsesByProjectID gets the responses of the requests of a project
+func (s *Store) GetResponsesByProjectID(ctx context.Context, projectID string) ([]*Response, error) {
+	snapshots, err := s.Client.Collection(ResponsesCollection).Where("project_id", "==", projectID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []*Response{}
+
+	for _, snapshot := range snapshots {
+		response := &Response{}
+		snapshot.DataTo(response)
+
+		if response.Deleted == nil {
+			responses = append(responses, response)
+		}
+	}
+
+	return responses, nil
+}
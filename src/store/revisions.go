@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+
+	"apiboy/backend/src/enums"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+)
+
+// RevisionsCollection is the name of the collection
+const RevisionsCollection = "revisions"
+
+// Revision represents a model in the database, it contains a snapshot
+// of a request or an environment as it was saved at some point
+type Revision struct {
+	ID          string       `json:"id" firestore:"id"`
+	EntityType  string       `json:"entity_type" firestore:"entity_type"`
+	EntityID    string       `json:"entity_id" firestore:"entity_id"`
+	ProjectID   string       `json:"project_id" firestore:"project_id"`
+	Request     *Request     `json:"request,omitempty" firestore:"request,omitempty"`
+	Environment *Environment `json:"environment,omitempty" firestore:"environment,omitempty"`
+	Created     *Event       `json:"created" firestore:"created"`
+}
+
+// NewRevisionID generates a UUID for revisions
+func (s *Store) NewRevisionID() string {
+	return "rev-" + uuid.New().String()
+}
+
+// newRequestRevision returns a revision with a copy of the given request, without the secrets of its auth
+func (s *Store) newRequestRevision(userID string, request *Request) *Revision {
+	snapshot := *request
+	snapshot.Auth = request.Auth.Redacted()
+
+	return &Revision{
+		ID:         s.NewRevisionID(),
+		EntityType: enums.EntityTypeRequest,
+		EntityID:   request.ID,
+		ProjectID:  request.ProjectID,
+		Request:    &snapshot,
+		Created:    NewEvent(userID),
+	}
+}
+
+// newEnvironmentRevision returns a revision with a copy of the given environment
+func (s *Store) newEnvironmentRevision(userID string, environment *Environment) *Revision {
+	snapshot := *environment
+
+	return &Revision{
+		ID:          s.NewRevisionID(),
+		EntityType:  enums.EntityTypeEnvironment,
+		EntityID:    environment.ID,
+		ProjectID:   environment.ProjectID,
+		Environment: &snapshot,
+		Created:     NewEvent(userID),
+	}
+}
+
+// migrate converts the data saved by older versions of the snapshot
+func (r *Revision) migrate() {
+	if r.Request != nil {
+		r.Request.migrate()
+	}
+}
+
+// GetRevisionByID gets a Revision by id
+func (s *Store) GetRevisionByID(ctx context.Context, id string) (*Revision, error) {
+	iter := s.Client.Collection(RevisionsCollection).Where("id", "==", id).Limit(1).Documents(ctx)
+
+	snapshot, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &Revision{}
+	snapshot.DataTo(revision)
+	revision.migrate()
+
+	return revision, nil
+}
+
+// GetRevisionsByEntityID gets a page of the revisions of an entity, sorted from newest to oldest.
+// The cursor is the id of the last revision of the previous page, and the returned cursor
+// is empty when there are no more pages.
+func (s *Store) GetRevisionsByEntityID(ctx context.Context, entityID, cursor string, limit int) ([]*Revision, string, error) {
+	q := s.Client.Collection(RevisionsCollection).Where("entity_id", "==", entityID).OrderBy("created.at", firestore.Desc)
+
+	if cursor != "" {
+		snapshot, err := s.Client.Collection(RevisionsCollection).Doc(cursor).Get(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		q = q.StartAfter(snapshot)
+	}
+
+	snapshots, err := q.Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	revisions := []*Revision{}
+	nextCursor := ""
+
+	for _, snapshot := range snapshots {
+		revision := &Revision{}
+		snapshot.DataTo(revision)
+		revision.migrate()
+
+		revisions = append(revisions, revision)
+		nextCursor = revision.ID
+	}
+
+	if len(snapshots) < limit {
+		nextCursor = ""
+	}
+
+	return revisions, nextCursor, nil
+}
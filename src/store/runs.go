@@ -0,0 +1,258 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"apiboy/backend/src/enums"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	// RunsCollection is the name of the collection
+	RunsCollection = "runs"
+
+	// RunResultsCollection is the name of the subcollection of the results of a run
+	RunResultsCollection = "results"
+)
+
+// Run represents a model in the database, it contains the progress of a run of the requests
+// of a folder or project (the folder is empty when the whole project runs). The requests run once
+// per row of the data file (optional), with its columns as variables, and the iterations run at
+// the same time up to the concurrency. The results are saved in a subcollection of the run, and the
+// iterations are calculated from them, so the document only keeps the counters. The instance that
+// executes the run updates its heartbeat while the run is queued or running.
+type Run struct {
+	ID             string          `json:"id" firestore:"id"`
+	ProjectID      string          `json:"project_id" firestore:"project_id"`
+	FolderID       string          `json:"folder_id" firestore:"folder_id"`
+	EnvironmentID  string          `json:"environment_id" firestore:"environment_id"`
+	StopOnFailure  bool            `json:"stop_on_failure" firestore:"stop_on_failure"`
+	DataBlobID     string          `json:"data_blob_id" firestore:"data_blob_id"`
+	Concurrency    int             `json:"concurrency" firestore:"concurrency"`
+	IterationCount int             `json:"iteration_count" firestore:"iteration_count"`
+	Status         string          `json:"status" firestore:"status"`
+	Total          int             `json:"total" firestore:"total"`
+	Completed      int             `json:"completed" firestore:"completed"`
+	Passed         int             `json:"passed" firestore:"passed"`
+	Failed         int             `json:"failed" firestore:"failed"`
+	Iterations     []*RunIteration `json:"iterations" firestore:"-"`
+	Results        []*RunResult    `json:"results" firestore:"-"`
+	Error          string          `json:"error" firestore:"error"`
+	Heartbeat      time.Time       `json:"heartbeat" firestore:"heartbeat"`
+	Created        *Event          `json:"created" firestore:"created"`
+	Started        *Event          `json:"started" firestore:"started"`
+	Finished       *Event          `json:"finished" firestore:"finished"`
+}
+
+// RunIteration contains the progress of an iteration of a run, the iterations are numbered from 1
+type RunIteration struct {
+	Iteration int `json:"iteration" firestore:"iteration"`
+	Completed int `json:"completed" firestore:"completed"`
+	Passed    int `json:"passed" firestore:"passed"`
+	Failed    int `json:"failed" firestore:"failed"`
+}
+
+// RunResult is the result of a request executed by an iteration of a run, the error describes why
+// the request could not be sent or a script failed. The steps are the positions of the requests in the run,
+// numbered from 1.
+type RunResult struct {
+	Iteration  int         `json:"iteration" firestore:"iteration"`
+	Step       int         `json:"step" firestore:"step"`
+	RequestID  string      `json:"request_id" firestore:"request_id"`
+	Name       string      `json:"name" firestore:"name"`
+	StatusCode int         `json:"status_code" firestore:"status_code"`
+	Time       int64       `json:"time" firestore:"time"`
+	Passed     bool        `json:"passed" firestore:"passed"`
+	Error      string      `json:"error" firestore:"error"`
+	Checks     []*RunCheck `json:"checks" firestore:"checks"`
+}
+
+// RunCheck is the result of an assertion, capture or test of a request executed by a run
+type RunCheck struct {
+	Type    string `json:"type" firestore:"type"`
+	Name    string `json:"name" firestore:"name"`
+	Passed  bool   `json:"passed" firestore:"passed"`
+	Message string `json:"message" firestore:"message"`
+}
+
+// NewRunID generates a UUID for runs
+func (s *Store) NewRunID() string {
+	return "run-" + uuid.New().String()
+}
+
+// CreateRun creates a new Run
+func (s *Store) CreateRun(ctx context.Context, userID string, run *Run) error {
+	run.Created = NewEvent(userID)
+	run.Heartbeat = run.Created.At
+	run.SetResults(nil)
+	_, err := s.Client.Collection(RunsCollection).Doc(run.ID).Set(ctx, run)
+	return err
+}
+
+// IsStale returns if a run is not finished and its heartbeat is older than the timeout,
+// which means that the instance executing it stopped
+func (r *Run) IsStale(timeout time.Duration) bool {
+	return !enums.IsFinishedRunStatus(r.Status) && time.Since(r.Heartbeat) > timeout
+}
+
+// SetResults sets the results of a run sorted by iteration and step, and calculates the progress of its iterations
+func (r *Run) SetResults(results []*RunResult) {
+	sortRunResults(results)
+
+	r.Results = append([]*RunResult{}, results...)
+	r.Iterations = []*RunIteration{}
+
+	for i := 0; i < r.IterationCount; i++ {
+		r.Iterations = append(r.Iterations, &RunIteration{Iteration: i + 1})
+	}
+
+	for _, result := range r.Results {
+		if result.Iteration < 1 || result.Iteration > len(r.Iterations) {
+			continue
+		}
+
+		iteration := r.Iterations[result.Iteration-1]
+		iteration.Completed++
+
+		if result.Passed {
+			iteration.Passed++
+		} else {
+			iteration.Failed++
+		}
+	}
+}
+
+// AddRunResult saves the result of a request of a run and increments the counters of the run without
+// a transaction, so the iterations that run at the same time don't contend for the document of the run.
+// The id of the result is its iteration and step, so saving it again fails instead of counting it twice.
+// It returns the updated run.
+func (s *Store) AddRunResult(ctx context.Context, runID string, result *RunResult) (*Run, error) {
+	runRef := s.Client.Collection(RunsCollection).Doc(runID)
+	resultRef := runRef.Collection(RunResultsCollection).Doc(fmt.Sprintf("%d-%d", result.Iteration, result.Step))
+
+	counter := "failed"
+	if result.Passed {
+		counter = "passed"
+	}
+
+	batch := s.Client.Batch()
+	batch.Create(resultRef, result)
+	batch.Update(runRef, []firestore.Update{
+		{Path: "completed", Value: firestore.Increment(1)},
+		{Path: counter, Value: firestore.Increment(1)},
+	})
+
+	if _, err := batch.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := runRef.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &Run{}
+	snapshot.DataTo(run)
+
+	return run, nil
+}
+
+// UpdateRunHeartbeats updates the heartbeat of the runs executed by this instance
+func (s *Store) UpdateRunHeartbeats(ctx context.Context, ids []string) error {
+	batch := s.Client.Batch()
+	now := time.Now().UTC()
+
+	for _, id := range ids {
+		batch.Update(s.Client.Collection(RunsCollection).Doc(id), []firestore.Update{
+			{Path: "heartbeat", Value: now},
+		})
+	}
+
+	_, err := batch.Commit(ctx)
+	return err
+}
+
+// GetRunResults gets the results of a run, sorted by iteration and step
+func (s *Store) GetRunResults(ctx context.Context, runID string) ([]*RunResult, error) {
+	iter := s.Client.Collection(RunsCollection).Doc(runID).Collection(RunResultsCollection).Documents(ctx)
+	results := []*RunResult{}
+
+	for {
+		snapshot, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result := &RunResult{}
+		snapshot.DataTo(result)
+		results = append(results, result)
+	}
+
+	sortRunResults(results)
+
+	return results, nil
+}
+
+// PatchRun applies a patch to the stored run in a transaction, so the changes of the status by the
+// workers and the cancellations of the users don't overwrite each other nor the counters
+func (s *Store) PatchRun(ctx context.Context, id string, patch func(run *Run)) (*Run, error) {
+	ref := s.Client.Collection(RunsCollection).Doc(id)
+	run := &Run{}
+
+	err := s.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+
+		*run = Run{}
+		snapshot.DataTo(run)
+
+		patch(run)
+
+		return tx.Set(ref, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// GetRunByID gets a Run by id
+func (s *Store) GetRunByID(ctx context.Context, id string) (*Run, error) {
+	iter := s.Client.Collection(RunsCollection).Where("id", "==", id).Limit(1).Documents(ctx)
+
+	snapshot, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := &Run{}
+	snapshot.DataTo(run)
+
+	return run, nil
+}
+
+// sortRunResults sorts the results of a run by iteration and step
+func sortRunResults(results []*RunResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Iteration != results[j].Iteration {
+			return results[i].Iteration < results[j].Iteration
+		}
+
+		return results[i].Step < results[j].Step
+	})
+}
@@ -10,11 +10,13 @@ import (
 // TokensCollection is the name of the collection
 const TokensCollection = "tokens"
 
-// Token represents a model in the database
+// Token represents a model in the database, the tokens of a password reset
+// can only be used to change the password of the user or to log out
 type Token struct {
-	ID      string `json:"id" firestore:"id"`
-	UserID  string `json:"user_id" firestore:"user_id"`
-	Created *Event `json:"created" firestore:"created"`
+	ID            string `json:"id" firestore:"id"`
+	UserID        string `json:"user_id" firestore:"user_id"`
+	PasswordReset bool   `json:"password_reset" firestore:"password_reset"`
+	Created       *Event `json:"created" firestore:"created"`
 }
 
 // NewTokenID generates a UUID for tokens
@@ -52,3 +54,38 @@ func (s *Store) GetTokenByID(ctx context.Context, id string) (*Token, error) {
 
 	return token, nil
 }
+
+// GetTokensByUserID gets all the tokens of a user
+func (s *Store) GetTokensByUserID(ctx context.Context, userID string) ([]*Token, error) {
+	snapshots, err := s.Client.Collection(TokensCollection).Where("user_id", "==", userID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []*Token{}
+
+	for _, snapshot := range snapshots {
+		token := &Token{}
+		snapshot.DataTo(token)
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// DeleteTokensByUserID deletes all the tokens of a user, closing all of its sessions
+func (s *Store) DeleteTokensByUserID(ctx context.Context, userID string) error {
+	tokens, err := s.GetTokensByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err := s.DeleteToken(ctx, token.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 
+	"cloud.google.com/go/firestore"
 	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 )
@@ -12,15 +13,17 @@ const UsersCollection = "users"
 
 // User represents a model in the database
 type User struct {
-	ID       string `json:"id" firestore:"id"`
-	Name     string `json:"name" firestore:"name"`
-	Email    string `json:"email" firestore:"email"`
-	Password string `json:"-" firestore:"password"`
-	Role     string `json:"role" firestore:"role"`
-	TempCode string `json:"-" firestore:"temp_code"`
-	Created  *Event `json:"created" firestore:"created"`
-	Updated  *Event `json:"updated" firestore:"updated"`
-	Deleted  *Event `json:"deleted" firestore:"deleted"`
+	ID                    string `json:"id" firestore:"id"`
+	Name                  string `json:"name" firestore:"name"`
+	Email                 string `json:"email" firestore:"email"`
+	Password              string `json:"-" firestore:"password"`
+	Role                  string `json:"role" firestore:"role"`
+	TempCode              string `json:"-" firestore:"temp_code"`
+	Disabled              bool   `json:"disabled" firestore:"disabled"`
+	PasswordResetRequired bool   `json:"password_reset_required" firestore:"password_reset_required"`
+	Created               *Event `json:"created" firestore:"created"`
+	Updated               *Event `json:"updated" firestore:"updated"`
+	Deleted               *Event `json:"deleted" firestore:"deleted"`
 }
 
 // NewUserID generates a UUID for users
@@ -80,3 +83,43 @@ func (s *Store) getUserByField(ctx context.Context, field, value string) (*User,
 
 	return user, nil
 }
+
+// SearchUsers gets a page of users whose email starts with the given query, sorted by email.
+// The cursor is the email of the last user of the previous page, and the returned cursor
+// is empty when there are no more pages.
+func (s *Store) SearchUsers(ctx context.Context, query, cursor string, limit int) ([]*User, string, error) {
+	q := s.Client.Collection(UsersCollection).OrderBy("email", firestore.Asc)
+
+	if query != "" {
+		q = q.Where("email", ">=", query).Where("email", "<", query+"\uf8ff")
+	}
+
+	if cursor != "" {
+		q = q.StartAfter(cursor)
+	}
+
+	snapshots, err := q.Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	users := []*User{}
+	nextCursor := ""
+
+	for _, snapshot := range snapshots {
+		user := &User{}
+		snapshot.DataTo(user)
+
+		if user.Deleted == nil {
+			users = append(users, user)
+		}
+
+		nextCursor = user.Email
+	}
+
+	if len(snapshots) < limit {
+		nextCursor = ""
+	}
+
+	return users, nextCursor, nil
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"apiboy/backend/src/errors"
+
+	"cloud.google.com/go/firestore"
+)
+
+// checkVersion returns the version of a stored document, or a Conflict error with the
+// stored document decoded into current if its version is not the expected one.
+// Documents saved before versions were introduced have version 0, and an expected
+// version 0 accepts any stored version.
+func checkVersion(snapshot *firestore.DocumentSnapshot, obj string, expected int64, current interface{}) (int64, error) {
+	var version int64
+
+	if value, err := snapshot.DataAt("version"); err == nil {
+		version, _ = value.(int64)
+	}
+
+	if expected != 0 && version != expected {
+		snapshot.DataTo(current)
+
+		if m, ok := current.(interface{ migrate() }); ok {
+			m.migrate()
+		}
+
+		return 0, errors.Conflict{Obj: obj, Current: current}
+	}
+
+	return version, nil
+}